@@ -2,10 +2,11 @@ package nanny
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
 	"net"
 	"net/http"
 	"os"
-	"os/signal"
 	"sync"
 	"time"
 
@@ -24,23 +25,28 @@ var DefaultApp Plugin = []Option{
 	WithGzip(DefaultGzipConfig),
 	WithTimeout(1 * time.Second),
 	WithPProf(":8081"),
+	WithServerConfig(DefaultServerConfig),
 }
 
 // New creates a new application.
 func New(opts ...Option) *Application {
 	app := &Application{
-		addr:           ":8080",
-		container:      inject.New(),
-		logger:         defaultLogger(),
-		readyCh:        make(chan struct{}),
-		shutdownSignal: make(chan struct{}),
+		container:               inject.New(),
+		logger:                  defaultLogger(),
+		methodNotAllowedHandler: defaultMethodNotAllowedHandler,
+		notFoundHandler:         defaultNotFoundHandler,
+		overrides:               map[string]bool{},
+		readyCh:                 make(chan struct{}),
+		shutdownSignal:          make(chan struct{}),
+		shutdownSignals:         defaultShutdownSignals(),
 	}
 
 	app.applyOpts([]Option{
 		injectTimeoutMiddleware(),
 		contextInjector(),
 		WithDecoder(newDecoder()),
-		WithEncoder(defaultEncoder{}),
+		withDefaultValidator(),
+		WithEncoder(newEncoder()),
 	})
 
 	app.applyOpts(opts)
@@ -50,6 +56,10 @@ func New(opts ...Option) *Application {
 		app:          app,
 	}
 
+	if app.healthCheck != nil {
+		app.registerHealthCheck()
+	}
+
 	return app
 }
 
@@ -61,29 +71,76 @@ func Default(opts ...Option) *Application {
 
 // Application is a web application.
 type Application struct {
+	// inFlight is accessed atomically so it's kept first for 64-bit alignment.
+	inFlight int64
+
 	*RouteGroup
 
-	addr         string
-	container    *inject.Container
-	logger       Logger
-	pprofSrv     *http.Server
-	readyCh      chan struct{}
-	routeOptions []RouteOption
-	routes       []*route
-	srv          *http.Server
-	wg           sync.WaitGroup
+	addrs                   []string
+	container               *inject.Container
+	h2c                     bool
+	handler                 http.Handler
+	handlerErr              error
+	handlerOnce             sync.Once
+	healthCheck             *HealthCheckConfig
+	healthCheckers          []namedHealthChecker
+	lifecycle               []lifecycleHook
+	listeners               []net.Listener
+	logger                  Logger
+	methodNotAllowedHandler Handler
+	notFoundHandler         Handler
+	overrides               map[string]bool
+	pprofSrv                *http.Server
+	readyCh                 chan struct{}
+	readyOnce               sync.Once
+	routeLogging            bool
+	routeOptions            []RouteOption
+	routes                  []*route
+	running                 bool
+	serverConfig            ServerConfig
+	socketActivation        bool
+	srv                     *http.Server
+	tlsConfig               *tls.Config
+	wg                      sync.WaitGroup
+
+	errOnce sync.Once
+	runErr  error
+
+	shutdownDelay   time.Duration
+	shutdownOnce    sync.Once
+	shutdownSignal  chan struct{}
+	shutdownSignals []os.Signal
+	shutdownTimeout time.Duration
+}
 
-	shutdownOnce   sync.Once
-	shutdownSignal chan struct{}
+// Run starts an HTTP server. It blocks until the server is stopped and
+// returns the first fatal error from serving or shutting down.
+func (app *Application) Run() error {
+	return app.RunContext(context.Background())
 }
 
-// Run starts an HTTP server.
-func (app *Application) Run() {
+// RunContext is similar to Run but the application is also stopped gracefully
+// when ctx is cancelled.
+// Components are started before serving requests and stopped after the HTTP server is drained.
+// Routes are validated before starting, so conflicting or duplicate routes are reported as an error.
+func (app *Application) RunContext(ctx context.Context) error {
+	if err := app.validateRoutes(); err != nil {
+		return err
+	}
+
+	app.running = true
+	if err := app.start(ctx); err != nil {
+		return err
+	}
+
+	app.logRoutes()
 	app.startHTTPServer()
 	app.startPProfServer()
-	app.setupGracefulShutdown()
+	app.setupGracefulShutdown(ctx)
 
 	app.wg.Wait()
+	app.stop(app.lifecycle)
+	return app.runErr
 }
 
 // Component finds and returns a component via name.
@@ -99,13 +156,26 @@ func (app *Application) MustComponent(name string) interface{} {
 }
 
 // Register registers a new component to the application.
+// If the component implements Starter or Stopper, it will be started and stopped with the application.
+// The component is ignored if another component is registered with the same name via WithOverride.
 func (app *Application) Register(name string, component interface{}) error {
-	return app.container.Register(name, component)
+	if app.overrides[name] {
+		return nil
+	}
+
+	if err := app.container.Register(name, component); err != nil {
+		return err
+	}
+
+	app.registerLifecycle(component)
+	return nil
 }
 
 // MustRegister registers a new component to the application. It panics if there is any error.
 func (app *Application) MustRegister(name string, component interface{}) {
-	app.container.MustRegister(name, component)
+	if err := app.Register(name, component); err != nil {
+		panic(err)
+	}
 }
 
 // execute starts a function in a goroutine.
@@ -118,6 +188,37 @@ func (app *Application) execute(fn func()) {
 	}()
 }
 
+// Handler returns the http.Handler which serves all registered routes.
+// The handler is built once on the first call, so routes must be registered before calling it.
+// It can be used with httptest, mounted under another mux or served by a custom http.Server.
+// It panics with RouteErrors if there are conflicting or duplicate routes.
+// When the handler is served outside Run, the application is ready as soon as the handler is built
+// as components aren't started by the application.
+func (app *Application) Handler() http.Handler {
+	app.handlerOnce.Do(func() {
+		// the error is kept, so every call panics with it rather than returning a nil handler
+		if app.handlerErr = app.validateRoutes(); app.handlerErr != nil {
+			return
+		}
+
+		app.handler = app.buildHTTPHandler()
+		if !app.running {
+			app.markReady()
+		}
+	})
+
+	if app.handlerErr != nil {
+		panic(app.handlerErr)
+	}
+
+	return app.handler
+}
+
+// ServeHTTP implements http.Handler by delegating to Handler.
+func (app *Application) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	app.Handler().ServeHTTP(w, req)
+}
+
 func (app *Application) buildHTTPHandler() http.Handler {
 	router := httprouter.New()
 
@@ -125,32 +226,48 @@ func (app *Application) buildHTTPHandler() http.Handler {
 		router.Handle(r.method, r.path, r.buildHandle())
 	}
 
+	router.NotFound = app.buildAppLevelHandler(app.notFoundHandler)
+	router.MethodNotAllowed = app.buildAppLevelHandler(app.methodNotAllowedHandler)
+	router.GlobalOPTIONS = app.buildAppLevelHandler(preflightHandler)
+
 	return router
 }
 
-// ListenAndServe listens on the TCP network address addr and then calls
-// Serve with handler to handle requests on incoming connections.
-// Accepted connections are configured to enable TCP keep-alives.
+// startHTTPServer opens all listeners and serves requests on incoming connections.
 func (app *Application) startHTTPServer() {
-	app.execute(func() {
-		app.srv = &http.Server{
-			Addr:    app.addr,
-			Handler: app.buildHTTPHandler(),
-		}
+	app.srv = &http.Server{
+		Handler:   app.wrapH2C(app.trackInFlight(app.Handler())),
+		TLSConfig: app.tlsConfig,
+	}
+	app.serverConfig.applyServer(app.srv)
 
-		ln, err := net.Listen("tcp", app.addr)
+	app.execute(func() {
+		listeners, err := app.listen()
 		if err != nil {
-			app.logger.Println("Error when listening on", app.addr)
+			app.fail(err)
 			return
 		}
 
-		close(app.readyCh)
-		app.logger.Println("Serving at addr", app.addr)
-		if err := app.srv.Serve(ln); err != nil && err != http.ErrServerClosed {
-			app.logger.Println("Error when starting HTTP service", err)
+		for _, ln := range listeners {
+			ln := app.serverConfig.limitListener(ln)
+			app.execute(func() {
+				app.logger.Println("Serving at addr", ln.Addr())
+				if err := app.serve(app.srv, ln); err != nil && err != http.ErrServerClosed {
+					app.fail(fmt.Errorf("nanny: error when serving HTTP on %s: %w", ln.Addr(), err))
+				}
+
+				app.shutdown()
+			})
 		}
 
-		app.shutdown()
+		app.markReady()
+	})
+}
+
+// markReady marks the application ready to serve requests.
+func (app *Application) markReady() {
+	app.readyOnce.Do(func() {
+		close(app.readyCh)
 	})
 }
 
@@ -159,37 +276,18 @@ func (app *Application) startPProfServer() {
 		return
 	}
 
+	app.serverConfig.applyServer(app.pprofSrv)
 	app.execute(func() {
-		if err := app.pprofSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			app.logger.Println("Error while starting pprof service", err)
-		}
-		app.shutdown()
-	})
-}
-
-// setupGracefulShutdown starts a goroutine for interrupt signal and proceed with graceful shutdown.
-func (app *Application) setupGracefulShutdown() {
-	app.execute(func() {
-		sigint := make(chan os.Signal, 1)
-		signal.Notify(sigint, os.Interrupt)
-		select {
-		case <-sigint:
-		case <-app.shutdownSignal:
+		ln, err := net.Listen("tcp", app.pprofSrv.Addr)
+		if err != nil {
+			app.fail(fmt.Errorf("nanny: error when listening on %s: %w", app.pprofSrv.Addr, err))
+			return
 		}
 
-		app.execute(func() {
-			// We received an interrupt signal, shut down.
-			if err := app.srv.Shutdown(context.Background()); err != nil && err != http.ErrServerClosed {
-				// Error from closing listeners, or context timeout:
-				app.logger.Println("HTTP server Shutdown: %v", err)
-			}
-		})
-
-		if app.pprofSrv != nil {
-			app.execute(func() {
-				_ = app.pprofSrv.Shutdown(context.Background())
-			})
+		if err := app.pprofSrv.Serve(app.serverConfig.limitListener(ln)); err != nil && err != http.ErrServerClosed {
+			app.fail(fmt.Errorf("nanny: error when serving pprof: %w", err))
 		}
+		app.shutdown()
 	})
 }
 
@@ -199,6 +297,16 @@ func (app *Application) shutdown() {
 	})
 }
 
+// fail records err as the result of Run if it's the first fatal error and stops the application.
+func (app *Application) fail(err error) {
+	app.errOnce.Do(func() {
+		app.runErr = err
+	})
+
+	app.logger.Println(err)
+	app.shutdown()
+}
+
 func (app *Application) applyOpts(opts []Option) {
 	for _, o := range opts {
 		if o != nil {
@@ -3,6 +3,7 @@ package nanny
 import (
 	"context"
 	"log"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -14,7 +15,7 @@ import (
 
 func executeRequest(app *Application, req *http.Request) *httptest.ResponseRecorder {
 	rr := httptest.NewRecorder()
-	app.buildHTTPHandler().ServeHTTP(rr, req)
+	app.Handler().ServeHTTP(rr, req)
 
 	return rr
 }
@@ -70,6 +71,67 @@ func Test_PATCH(t *testing.T) {
 	require.Equal(t, http.StatusOK, resp.Code)
 }
 
+func Test_HEAD(t *testing.T) {
+	app := New()
+	app.HEAD("/mock-endpoint", func(ctx context.Context, req Request) (interface{}, error) {
+		return nil, nil
+	})
+	req, _ := http.NewRequest("HEAD", "/mock-endpoint", nil)
+	resp := executeRequest(app, req)
+	require.Equal(t, http.StatusNoContent, resp.Code)
+}
+
+func Test_OPTIONS(t *testing.T) {
+	app := New()
+	app.OPTIONS("/mock-endpoint", func(ctx context.Context, req Request) (interface{}, error) {
+		return "OK", nil
+	})
+	req, _ := http.NewRequest("OPTIONS", "/mock-endpoint", nil)
+	resp := executeRequest(app, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+}
+
+func Test_Handle(t *testing.T) {
+	app := New()
+	app.Handle("PROPFIND", "/mock-endpoint", func(ctx context.Context, req Request) (interface{}, error) {
+		return "OK", nil
+	})
+	req, _ := http.NewRequest("PROPFIND", "/mock-endpoint", nil)
+	resp := executeRequest(app, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+}
+
+func Test_Any(t *testing.T) {
+	app := New()
+	app.Any("/mock-endpoint", func(ctx context.Context, req Request) (interface{}, error) {
+		return req.HTTPRequest().Method, nil
+	})
+
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodDelete, http.MethodOptions} {
+		req, _ := http.NewRequest(method, "/mock-endpoint", nil)
+		resp := executeRequest(app, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, "\""+method+"\"\n", resp.Body.String())
+	}
+}
+
+func Test_Handler(t *testing.T) {
+	app := New()
+	app.GET("/mock-endpoint", func(ctx context.Context, req Request) (interface{}, error) {
+		return "OK", nil
+	})
+
+	h := app.Handler()
+	require.True(t, h == app.Handler(), "handler must be built once")
+
+	mux := http.NewServeMux()
+	mux.Handle("/", app)
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/mock-endpoint", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "\"OK\"\n", rr.Body.String())
+}
+
 func Test_graceful_shutdown(t *testing.T) {
 	runFinished := make(chan struct{})
 
@@ -77,7 +139,7 @@ func Test_graceful_shutdown(t *testing.T) {
 
 	go func() {
 		require.NotPanics(t, func() {
-			app.Run()
+			require.NoError(t, app.Run())
 			close(runFinished)
 		})
 	}()
@@ -96,6 +158,46 @@ func Test_graceful_shutdown(t *testing.T) {
 	}
 }
 
+func Test_RunContext(t *testing.T) {
+	app := New(WithAddress(":0"))
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+
+	go func() {
+		runErr <- app.RunContext(ctx)
+	}()
+
+	<-app.readyCh
+	cancel()
+
+	select {
+	case <-time.After(1 * time.Second):
+		require.Fail(t, "Test times out")
+	case err := <-runErr:
+		require.NoError(t, err)
+	}
+}
+
+func Test_Run_listenError(t *testing.T) {
+	ln, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	app := New(WithAddress(ln.Addr().String()))
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- app.Run()
+	}()
+
+	select {
+	case <-time.After(1 * time.Second):
+		require.Fail(t, "Test times out")
+	case err := <-runErr:
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error when listening")
+	}
+}
+
 func Test_applyOpts(t *testing.T) {
 	logger := log.New(os.Stdout, "", log.LstdFlags)
 	opt := WithLogger(logger)
@@ -106,7 +208,7 @@ func Test_applyOpts(t *testing.T) {
 
 func Test_Default(t *testing.T) {
 	app := Default()
-	require.Len(t, app.routeOptions, 8)
+	require.Len(t, app.routeOptions, 9)
 	require.NotNil(t, app.logger)
 	require.NotNil(t, app.pprofSrv)
 }
@@ -137,3 +239,15 @@ func Test_Component(t *testing.T) {
 	require.True(t, ok)
 	require.NotNil(t, app.MustComponent("logger"))
 }
+
+func Test_Handler_invalidRoutes(t *testing.T) {
+	app := New()
+	app.GET("/users/:id", mockHandler)
+	app.GET("/users/new", mockHandler)
+
+	for i := 0; i < 2; i++ {
+		require.PanicsWithError(t, app.validateRoutes().Error(), func() {
+			app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/1", nil))
+		})
+	}
+}
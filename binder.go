@@ -0,0 +1,371 @@
+package nanny
+
+import (
+	"encoding"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// tags to bind values from requests.
+const (
+	tagPath    = "path"
+	tagQuery   = "query"
+	tagHeader  = "header"
+	tagCookie  = "cookie"
+	tagForm    = "form"
+	tagSchema  = "schema"
+	tagDefault = "default"
+	tagJSON    = "json"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	fileHeaderType      = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeadersType     = reflect.TypeOf([]*multipart.FileHeader(nil))
+	durationType        = reflect.TypeOf(time.Duration(0))
+
+	errUnsupportedType = errors.New("unsupported type")
+)
+
+// structFields caches fields of struct types for binding.
+var structFields sync.Map
+
+type bindingField struct {
+	index      []int
+	name       string
+	path       string
+	query      string
+	header     string
+	cookie     string
+	form       string
+	defaultVal string
+	hasDefault bool
+	file       bool
+	// bodyOnly is set for fields named by json tags, they aren't bound from query strings of requests which may have bodies.
+	bodyOnly bool
+}
+
+// bindingSource is a source of values for a field.
+type bindingSource struct {
+	name   string
+	key    func(f *bindingField) string
+	values func(key string) []string
+}
+
+// getBindingFields returns fields of a struct type which can be bound.
+func getBindingFields(t reflect.Type) []*bindingField {
+	if cached, ok := structFields.Load(t); ok {
+		return cached.([]*bindingField)
+	}
+
+	fields := parseBindingFields(t, nil)
+	structFields.Store(t, fields)
+	return fields
+}
+
+func parseBindingFields(t reflect.Type, parentIndex []int) []*bindingField {
+	var fields []*bindingField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		index := append(append([]int{}, parentIndex...), i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			fields = append(fields, parseBindingFields(sf.Type, index)...)
+			continue
+		}
+
+		// unexported fields
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		f := &bindingField{
+			index:  index,
+			name:   sf.Name,
+			path:   tagName(sf, tagPath),
+			query:  tagName(sf, tagQuery),
+			header: tagName(sf, tagHeader),
+			cookie: tagName(sf, tagCookie),
+		}
+		f.defaultVal, f.hasDefault = sf.Tag.Lookup(tagDefault)
+		f.file = sf.Type == fileHeaderType || sf.Type == fileHeadersType
+
+		// fields without explicit sources are bound from form values by name.
+		// Fields ignored by encoding/json are never bound and fields with json tags are only bound from query strings
+		// of GET and HEAD requests, so body fields can't be set by adding query params to a request with a body.
+		if f.path == "" && f.query == "" && f.header == "" && f.cookie == "" && sf.Tag.Get(tagJSON) != "-" {
+			f.form = tagName(sf, tagForm)
+			if f.form == "" {
+				f.form = tagName(sf, tagSchema)
+			}
+			if f.form == "" {
+				f.form = tagName(sf, tagJSON)
+				f.bodyOnly = f.form != ""
+			}
+			if f.form == "" {
+				f.form = sf.Name
+			}
+		}
+
+		if f.form == "-" {
+			f.form = ""
+		}
+
+		fields = append(fields, f)
+	}
+
+	return fields
+}
+
+func tagName(sf reflect.StructField, tag string) string {
+	value := sf.Tag.Get(tag)
+	if idx := strings.IndexByte(value, ','); idx >= 0 {
+		value = value[:idx]
+	}
+
+	return value
+}
+
+// bindDefaults sets values from default tags.
+func bindDefaults(v reflect.Value, fields []*bindingField) ParamErrors {
+	var errs ParamErrors
+	for _, f := range fields {
+		if !f.hasDefault {
+			continue
+		}
+
+		if err := setField(v.FieldByIndex(f.index), []string{f.defaultVal}); err != nil {
+			errs = append(errs, &ParamError{Source: tagDefault, Name: f.name, Err: err})
+		}
+	}
+
+	return errs
+}
+
+// bindForm sets values of fields without explicit sources from form values.
+// Fields with json tags are only bound from query strings of GET and HEAD requests.
+func bindForm(v reflect.Value, fields []*bindingField, req *http.Request) ParamErrors {
+	hasBody := req.Method != http.MethodGet && req.Method != http.MethodHead
+	var errs ParamErrors
+	for _, f := range fields {
+		if f.form == "" {
+			continue
+		}
+
+		if f.file {
+			bindFiles(v.FieldByIndex(f.index), req, f.form)
+			continue
+		}
+
+		form := req.Form
+		if f.bodyOnly && hasBody {
+			form = req.PostForm
+		}
+
+		values := formValues(form, f.form)
+		if len(values) == 0 {
+			continue
+		}
+
+		if err := setField(v.FieldByIndex(f.index), values); err != nil {
+			errs = append(errs, &ParamError{Source: tagForm, Name: f.form, Err: err})
+		}
+	}
+
+	return errs
+}
+
+// bindSources sets values of fields from path, query, header and cookie.
+// If there are values from many sources, path takes precedence over query, header and cookie in that order.
+// Fields without explicit sources are also bound from path params matching their form names, so the URL
+// takes precedence over the body.
+func bindSources(v reflect.Value, fields []*bindingField, req *http.Request) ParamErrors {
+	params := ParamsFromCtx(req.Context())
+	query := req.URL.Query()
+	sources := []bindingSource{
+		{
+			name: sourcePath,
+			key: func(f *bindingField) string {
+				if f.path != "" || f.file {
+					return f.path
+				}
+				return f.form
+			},
+			values: func(key string) []string {
+				if value, ok := paramValue(params, key); ok {
+					return []string{value}
+				}
+				return nil
+			},
+		},
+		{
+			name:   sourceQuery,
+			key:    func(f *bindingField) string { return f.query },
+			values: func(key string) []string { return query[key] },
+		},
+		{
+			name:   sourceHeader,
+			key:    func(f *bindingField) string { return f.header },
+			values: func(key string) []string { return req.Header.Values(key) },
+		},
+		{
+			name: sourceCookie,
+			key:  func(f *bindingField) string { return f.cookie },
+			values: func(key string) []string {
+				if c, err := req.Cookie(key); err == nil {
+					return []string{c.Value}
+				}
+				return nil
+			},
+		},
+	}
+
+	var errs ParamErrors
+	for _, f := range fields {
+		for _, s := range sources {
+			key := s.key(f)
+			if key == "" {
+				continue
+			}
+
+			values := s.values(key)
+			if len(values) == 0 {
+				continue
+			}
+
+			if err := setField(v.FieldByIndex(f.index), values); err != nil {
+				errs = append(errs, &ParamError{Source: s.name, Name: key, Err: err})
+			}
+			break
+		}
+	}
+
+	return errs
+}
+
+// bindFiles sets files of a multipart form to a *multipart.FileHeader or []*multipart.FileHeader field.
+func bindFiles(v reflect.Value, req *http.Request, name string) {
+	if req.MultipartForm == nil {
+		return
+	}
+
+	files := req.MultipartForm.File[name]
+	if len(files) == 0 {
+		return
+	}
+
+	if v.Type() == fileHeaderType {
+		v.Set(reflect.ValueOf(files[0]))
+		return
+	}
+
+	v.Set(reflect.ValueOf(files))
+}
+
+// formValues returns form values by name. The name is matched case-insensitively if there is no exact match.
+func formValues(form url.Values, name string) []string {
+	if values, ok := form[name]; ok {
+		return values
+	}
+
+	for key, values := range form {
+		if strings.EqualFold(key, name) {
+			return values
+		}
+	}
+
+	return nil
+}
+
+// paramValue returns the value of a path param by name. The name is matched case-insensitively.
+func paramValue(params httprouter.Params, name string) (string, bool) {
+	for _, p := range params {
+		if strings.EqualFold(p.Key, name) {
+			return p.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// setField converts values and sets them to a field.
+func setField(v reflect.Value, values []string) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+
+		return setField(v.Elem(), values)
+	}
+
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(values[0]))
+	}
+
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setField(slice.Index(i), []string{value}); err != nil {
+				return err
+			}
+		}
+
+		v.Set(slice)
+		return nil
+	}
+
+	return setValue(v, values[0])
+}
+
+func setValue(v reflect.Value, value string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return errors.New("not a duration")
+		}
+
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.New("not a boolean")
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return errInvalidInt
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return errors.New("not an unsigned integer")
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return errors.New("not a number")
+		}
+		v.SetFloat(f)
+	default:
+		return errUnsupportedType
+	}
+
+	return nil
+}
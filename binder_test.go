@@ -0,0 +1,165 @@
+package nanny
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/require"
+)
+
+type mockEmbeddedRequest struct {
+	Tenant string `header:"X-Tenant"`
+}
+
+type mockBindingRequest struct {
+	mockEmbeddedRequest
+	ID       int           `path:"id" query:"id"`
+	Page     int           `query:"page" default:"1"`
+	Limit    *uint         `query:"limit"`
+	Tags     []string      `query:"tag"`
+	Session  string        `cookie:"session"`
+	Timeout  time.Duration `query:"timeout" default:"5s"`
+	Verbose  bool          `header:"X-Verbose" query:"verbose"`
+	Name     string        `json:"name" query:"name"`
+	Note     string        `json:"note"`
+	Ignored  string        `form:"-"`
+	internal string
+}
+
+func decodeBinding(t *testing.T, req *http.Request, params httprouter.Params) (*mockBindingRequest, error) {
+	t.Helper()
+	require.NoError(t, req.ParseForm())
+	req = req.WithContext(context.WithValue(req.Context(), ctxKeyParams, params))
+	obj := &mockBindingRequest{}
+	err := newDecoder().Decode(obj, req)
+	return obj, err
+}
+
+func Test_defaultDecoder_Binding(t *testing.T) {
+	t.Run("sources", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/?id=2&limit=20&tag=a&tag=b&timeout=1m&verbose=true&name=query-name&ignored=x", strings.NewReader(`{"name":"body-name","note":"body-note"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Tenant", "mock-tenant")
+		req.Header.Set("X-Verbose", "false")
+		req.AddCookie(&http.Cookie{Name: "session", Value: "mock-session"})
+
+		obj, err := decodeBinding(t, req, httprouter.Params{{Key: "id", Value: "1"}})
+		require.NoError(t, err)
+		require.Equal(t, 1, obj.ID)
+		require.Equal(t, 1, obj.Page)
+		require.Equal(t, uint(20), *obj.Limit)
+		require.Equal(t, []string{"a", "b"}, obj.Tags)
+		require.Equal(t, "mock-session", obj.Session)
+		require.Equal(t, time.Minute, obj.Timeout)
+		require.True(t, obj.Verbose)
+		require.Equal(t, "query-name", obj.Name)
+		require.Equal(t, "body-note", obj.Note)
+		require.Equal(t, "mock-tenant", obj.Tenant)
+		require.Empty(t, obj.Ignored)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		obj, err := decodeBinding(t, httptest.NewRequest(http.MethodGet, "/", nil), nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, obj.Page)
+		require.Equal(t, 5*time.Second, obj.Timeout)
+		require.Nil(t, obj.Limit)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/?page=abc&timeout=abc", nil)
+		_, err := decodeBinding(t, req, httprouter.Params{{Key: "id", Value: "x"}})
+
+		var errs ParamErrors
+		require.True(t, errors.As(err, &errs))
+		require.Len(t, errs, 3)
+		require.Equal(t, &ParamError{Source: sourcePath, Name: "id", Err: errInvalidInt}, errs[0])
+		require.Equal(t, "query", errs[1].Source)
+		require.Equal(t, "page", errs[1].Name)
+		require.Equal(t, "timeout", errs[2].Name)
+
+		w := httptest.NewRecorder()
+		errs.WriteTo(w)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.JSONEq(t, `{"message":"Invalid Params","errors":[
+			{"source":"path","name":"id","message":"not an integer"},
+			{"source":"query","name":"page","message":"not an integer"},
+			{"source":"query","name":"timeout","message":"not a duration"}
+		]}`, w.Body.String())
+	})
+}
+
+func Test_Request_Decode_Binding(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/users/10?id=20", nil)
+	obj := &mockBindingRequest{}
+	err := NewRequest(req, httprouter.Params{{Key: "id", Value: "10"}}).Decode(obj)
+	require.NoError(t, err)
+	require.Equal(t, 10, obj.ID)
+}
+
+type mockMassAssignmentRequest struct {
+	Name    string `json:"name"`
+	IsAdmin bool   `json:"-"`
+}
+
+func Test_Request_Decode_MassAssignment(t *testing.T) {
+	t.Run("json-body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/users/10?isadmin=true&name=query", strings.NewReader(`{}`))
+		req.Header.Set(HeaderContentType, "application/json")
+		obj := &mockMassAssignmentRequest{}
+		err := NewRequest(req, httprouter.Params{{Key: "id", Value: "10"}}).Decode(obj)
+		require.NoError(t, err)
+		require.False(t, obj.IsAdmin)
+		require.Empty(t, obj.Name)
+		require.Empty(t, req.Form.Get("id"))
+	})
+
+	t.Run("form-body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=form&isadmin=true"))
+		req.Header.Set(HeaderContentType, "application/x-www-form-urlencoded")
+		obj := &mockMassAssignmentRequest{}
+		err := NewRequest(req, nil).Decode(obj)
+		require.NoError(t, err)
+		require.False(t, obj.IsAdmin)
+		require.Equal(t, "form", obj.Name)
+	})
+}
+
+type mockPathPrecedenceRequest struct {
+	ID   int    `json:"id"`
+	Page int    `json:"page" validate:"required"`
+	Name string `json:"name"`
+}
+
+func Test_Request_Decode_PathPrecedence(t *testing.T) {
+	params := httprouter.Params{{Key: "id", Value: "1"}}
+
+	t.Run("json-body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/users/1", strings.NewReader(`{"id":5,"page":2,"name":"json"}`))
+		req.Header.Set(HeaderContentType, "application/json")
+		obj := &mockPathPrecedenceRequest{}
+		require.NoError(t, NewRequest(req, params).Decode(obj))
+		require.Equal(t, &mockPathPrecedenceRequest{ID: 1, Page: 2, Name: "json"}, obj)
+	})
+
+	t.Run("form-body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/users/1", strings.NewReader("id=7&page=2&name=form"))
+		req.Header.Set(HeaderContentType, "application/x-www-form-urlencoded")
+		obj := &mockPathPrecedenceRequest{}
+		require.NoError(t, NewRequest(req, params).Decode(obj))
+		require.Equal(t, &mockPathPrecedenceRequest{ID: 1, Page: 2, Name: "form"}, obj)
+	})
+
+	t.Run("get-query", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/1?id=7&page=2", nil)
+		obj := &mockPathPrecedenceRequest{}
+		require.NoError(t, NewRequest(req, params).Decode(obj))
+		require.Equal(t, &mockPathPrecedenceRequest{ID: 1, Page: 2}, obj)
+	})
+}
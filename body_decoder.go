@@ -0,0 +1,145 @@
+package nanny
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// media types of request bodies.
+const (
+	mediaTypeForm      = "application/x-www-form-urlencoded"
+	mediaTypeMultipart = "multipart/form-data"
+)
+
+var unsupportedMediaTypeErr = HTTPError{Code: http.StatusUnsupportedMediaType, Message: "Unsupported Media Type"}
+
+// invalidBodyErr is returned when a body can't be unmarshalled.
+var invalidBodyErr = HTTPError{Code: http.StatusBadRequest, Message: "Invalid Request Body"}
+
+// BodyDecoderFunc decodes a request body to an object.
+type BodyDecoderFunc func(body io.Reader, obj interface{}) error
+
+// WithBodyDecoder registers a BodyDecoderFunc for a media type, e.g. "application/yaml".
+// A media type with a leading "+", e.g. "+json", registers a decoder for all media types with that suffix.
+// It only applies to the default Decoder.
+func WithBodyDecoder(mediaType string, fn BodyDecoderFunc) RouteOptionFn {
+	return func(r *route) {
+		d, ok := r.decoder.(*defaultDecoder)
+		if !ok {
+			return
+		}
+
+		r.decoder = d.withBodyDecoder(strings.ToLower(mediaType), fn)
+	}
+}
+
+func defaultBodyDecoders() map[string]BodyDecoderFunc {
+	return map[string]BodyDecoderFunc{
+		"application/json":        decodeJSON,
+		"+json":                   decodeJSON,
+		"application/xml":         decodeXML,
+		"text/xml":                decodeXML,
+		"+xml":                    decodeXML,
+		mediaTypeForm:             decodeForm,
+		mediaTypeMultipart:        decodeForm,
+		"application/msgpack":     decodeMsgpack,
+		"application/x-msgpack":   decodeMsgpack,
+		"application/vnd.msgpack": decodeMsgpack,
+		"application/protobuf":    decodeProtobuf,
+		"application/x-protobuf":  decodeProtobuf,
+	}
+}
+
+func (d *defaultDecoder) withBodyDecoder(mediaType string, fn BodyDecoderFunc) *defaultDecoder {
+	bodyDecoders := make(map[string]BodyDecoderFunc, len(d.bodyDecoders)+1)
+	for k, v := range d.bodyDecoders {
+		bodyDecoders[k] = v
+	}
+
+	bodyDecoders[mediaType] = fn
+	return &defaultDecoder{bodyDecoders: bodyDecoders}
+}
+
+// bodyDecoder returns the BodyDecoderFunc for a media type. Decoders of suffixes like "+json" are used if there is no exact match.
+func (d *defaultDecoder) bodyDecoder(mediaType string) BodyDecoderFunc {
+	if fn, ok := d.bodyDecoders[mediaType]; ok {
+		return fn
+	}
+
+	if idx := strings.LastIndexByte(mediaType, '+'); idx >= 0 {
+		return d.bodyDecoders[mediaType[idx:]]
+	}
+
+	return nil
+}
+
+// parseMediaType returns the media type of a request without parameters. It returns an empty string if there is no Content-Type.
+func parseMediaType(req *http.Request) (string, error) {
+	contentType := req.Header.Get(HeaderContentType)
+	if contentType == "" {
+		return "", nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", unsupportedMediaTypeErr
+	}
+
+	return strings.ToLower(mediaType), nil
+}
+
+func hasBody(req *http.Request) bool {
+	return req.Body != nil && req.Body != http.NoBody && req.ContentLength != 0
+}
+
+func decodeJSON(body io.Reader, obj interface{}) error {
+	return invalidBody(json.NewDecoder(body).Decode(obj))
+}
+
+func decodeXML(body io.Reader, obj interface{}) error {
+	return invalidBody(xml.NewDecoder(body).Decode(obj))
+}
+
+// decodeForm does nothing as form values are bound from req.Form.
+func decodeForm(body io.Reader, obj interface{}) error {
+	return nil
+}
+
+func decodeMsgpack(body io.Reader, obj interface{}) error {
+	return invalidBody(msgpack.NewDecoder(body).Decode(obj))
+}
+
+func decodeProtobuf(body io.Reader, obj interface{}) error {
+	msg, ok := obj.(proto.Message)
+	if !ok {
+		return fmt.Errorf("nanny: %T isn't a proto.Message", obj)
+	}
+
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	return invalidBody(proto.Unmarshal(data, msg))
+}
+
+// invalidBody converts an error of unmarshalling a body to invalidBodyErr.
+// Errors of invalid destinations are kept as they aren't caused by clients.
+func invalidBody(err error) error {
+	var invalidUnmarshalErr *json.InvalidUnmarshalError
+	if err == nil || errors.As(err, &invalidUnmarshalErr) {
+		return err
+	}
+
+	return invalidBodyErr
+}
@@ -0,0 +1,192 @@
+package nanny
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type mockBodyRequest struct {
+	Name string `json:"name" xml:"name" msgpack:"name"`
+	Age  int    `json:"age" xml:"age" msgpack:"age"`
+}
+
+type mockCustomDecoder struct{}
+
+func (d *mockCustomDecoder) Decode(obj interface{}, req *http.Request) error {
+	return nil
+}
+
+func decodeBody(t *testing.T, d Decoder, contentType string, body io.Reader, obj interface{}) error {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/", body)
+	req.Header.Set(HeaderContentType, contentType)
+	require.NoError(t, req.ParseForm())
+	return d.Decode(obj, req)
+}
+
+func Test_defaultDecoder_BodyDecoders(t *testing.T) {
+	msgpackBody, err := msgpack.Marshal(&mockBodyRequest{Name: "mock-name", Age: 10})
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		contentType string
+		body        string
+	}{
+		"json-charset": {
+			contentType: "application/json; charset=utf-8",
+			body:        `{"name":"mock-name","age":10}`,
+		},
+		"json-suffix": {
+			contentType: "application/vnd.api+json",
+			body:        `{"name":"mock-name","age":10}`,
+		},
+		"xml": {
+			contentType: "application/xml",
+			body:        `<mockBodyRequest><name>mock-name</name><age>10</age></mockBodyRequest>`,
+		},
+		"form": {
+			contentType: "application/x-www-form-urlencoded",
+			body:        `name=mock-name&age=10`,
+		},
+		"msgpack": {
+			contentType: "application/msgpack",
+			body:        string(msgpackBody),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			obj := &mockBodyRequest{}
+			err := decodeBody(t, newDecoder(), tc.contentType, strings.NewReader(tc.body), obj)
+			require.NoError(t, err)
+			require.Equal(t, &mockBodyRequest{Name: "mock-name", Age: 10}, obj)
+		})
+	}
+
+	t.Run("multipart", func(t *testing.T) {
+		body := &bytes.Buffer{}
+		mw := multipart.NewWriter(body)
+		require.NoError(t, mw.WriteField("name", "mock-name"))
+		require.NoError(t, mw.WriteField("age", "10"))
+		require.NoError(t, mw.Close())
+
+		obj := &mockBodyRequest{}
+		err := decodeBody(t, newDecoder(), mw.FormDataContentType(), body, obj)
+		require.NoError(t, err)
+		require.Equal(t, &mockBodyRequest{Name: "mock-name", Age: 10}, obj)
+	})
+
+	t.Run("protobuf", func(t *testing.T) {
+		body, err := proto.Marshal(wrapperspb.String("mock-value"))
+		require.NoError(t, err)
+
+		obj := &wrapperspb.StringValue{}
+		err = decodeBody(t, newDecoder(), "application/x-protobuf", bytes.NewReader(body), obj)
+		require.NoError(t, err)
+		require.Equal(t, "mock-value", obj.GetValue())
+
+		err = decodeBody(t, newDecoder(), "application/x-protobuf", bytes.NewReader(body), &mockBodyRequest{})
+		require.EqualError(t, err, "nanny: *nanny.mockBodyRequest isn't a proto.Message")
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		err := decodeBody(t, newDecoder(), "text/csv", strings.NewReader("a,b"), &mockBodyRequest{})
+		require.Equal(t, unsupportedMediaTypeErr, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("a,b"))
+		req.Header.Set(HeaderContentType, "invalid;;")
+		err = newDecoder().Decode(&mockBodyRequest{}, req)
+		require.Equal(t, unsupportedMediaTypeErr, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, contentType := range []string{"application/json", "application/xml", "application/msgpack", "application/x-protobuf"} {
+			err := decodeBody(t, newDecoder(), contentType, strings.NewReader("{bad"), &wrapperspb.StringValue{})
+			require.Equal(t, invalidBodyErr, err, contentType)
+		}
+
+		app := New()
+		app.POST("/users", func(ctx context.Context, req Request) (interface{}, error) {
+			return nil, req.Decode(&mockBodyRequest{})
+		})
+		app.POST("/limited", func(ctx context.Context, req Request) (interface{}, error) {
+			return nil, req.Decode(&mockBodyRequest{})
+		}, WithBodyLimit(1))
+
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":`))
+		req.Header.Set(HeaderContentType, "application/json")
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.JSONEq(t, `{"message":"Invalid Request Body"}`, w.Body.String())
+
+		for _, target := range []string{"/users", "/users?a=%zz"} {
+			body := "a=%zz"
+			if target != "/users" {
+				body = "a=b"
+			}
+
+			req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+			req.Header.Set(HeaderContentType, "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, req)
+			require.Equal(t, http.StatusBadRequest, w.Code, target)
+			require.JSONEq(t, `{"message":"Invalid Request Body"}`, w.Body.String())
+		}
+
+		req = httptest.NewRequest(http.MethodPost, "/limited", strings.NewReader("a=b"))
+		req.Header.Set(HeaderContentType, "application/x-www-form-urlencoded")
+		req.ContentLength = -1
+		w = httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+
+	t.Run("no-body", func(t *testing.T) {
+		err := decodeBody(t, newDecoder(), "text/csv", nil, &mockBodyRequest{})
+		require.NoError(t, err)
+	})
+}
+
+func Test_WithBodyDecoder(t *testing.T) {
+	r := &route{decoder: newDecoder()}
+	WithBodyDecoder("Text/CSV", func(body io.Reader, obj interface{}) error {
+		data, err := ioutil.ReadAll(body)
+		if err != nil {
+			return err
+		}
+
+		parts := strings.Split(string(data), ",")
+		obj.(*mockBodyRequest).Name = parts[0]
+		return nil
+	}).ApplyRoute(r)
+
+	obj := &mockBodyRequest{}
+	err := decodeBody(t, r.decoder, "text/csv", strings.NewReader("mock-name,10"), obj)
+	require.NoError(t, err)
+	require.Equal(t, "mock-name", obj.Name)
+
+	// the default decoder isn't changed
+	err = decodeBody(t, newDecoder(), "text/csv", strings.NewReader("mock-name,10"), obj)
+	require.Equal(t, unsupportedMediaTypeErr, err)
+
+	t.Run("custom-decoder", func(t *testing.T) {
+		d := &mockCustomDecoder{}
+		r := &route{decoder: d}
+		WithBodyDecoder("text/csv", nil).ApplyRoute(r)
+		require.Equal(t, d, r.decoder)
+	})
+}
@@ -0,0 +1,67 @@
+package nanny
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// WithBodyLimit limits the size of request bodies in bytes.
+// Requests with a larger Content-Length are rejected before calling the handler
+// and reading more than the limit from the body returns an error, both are responded with 413 status code.
+func WithBodyLimit(limit int64) RouteOptionFn {
+	return func(r *route) {
+		var t handleTransformer = func(next httprouter.Handle) httprouter.Handle {
+			return func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
+				if req.ContentLength > limit {
+					if errHandle := r.errorHandler(w, requestTooLargeErr); errHandle != nil {
+						r.logger.Println("Error", errHandle, "while handling error")
+					}
+					return
+				}
+
+				if req.Body != nil && req.Body != http.NoBody {
+					req.Body = &maxBytesReader{
+						ReadCloser: http.MaxBytesReader(w, req.Body, limit),
+						remaining:  limit,
+					}
+				}
+
+				next(w, req, params)
+			}
+		}
+
+		r.addTransformer("nanny.WithBodyLimit", t)
+	}
+}
+
+// maxBytesReader converts the error of http.MaxBytesReader into requestTooLargeErr when the body exceeds the limit.
+type maxBytesReader struct {
+	io.ReadCloser
+	remaining int64
+	exceeded  bool
+}
+
+func (r *maxBytesReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.remaining -= int64(n)
+	if err != nil && err != io.EOF && r.remaining <= 0 {
+		r.exceeded = true
+		return n, requestTooLargeErr
+	}
+
+	return n, err
+}
+
+// isBodyTooLarge checks if reading a request body failed because of a size limit.
+func isBodyTooLarge(body io.Reader) bool {
+	switch b := body.(type) {
+	case *maxBytesReader:
+		return b.exceeded
+	case *limitedReader:
+		return b.exceeded || isBodyTooLarge(b.ReadCloser)
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package nanny
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithBodyLimit(t *testing.T) {
+	app := New(WithBodyLimit(20))
+	app.POST("/json", func(ctx context.Context, req Request) (interface{}, error) {
+		reqObj := &mockBodyRequest{}
+		if err := req.Decode(reqObj); err != nil {
+			return nil, err
+		}
+
+		return reqObj, nil
+	})
+	app.POST("/raw", func(ctx context.Context, req Request) (interface{}, error) {
+		data, err := ioutil.ReadAll(req.HTTPRequest().Body)
+		if err != nil {
+			return nil, err
+		}
+
+		return string(data), nil
+	}, WithBodyLimit(5))
+
+	testCases := map[string]struct {
+		path         string
+		body         string
+		chunked      bool
+		expectedCode int
+	}{
+		"within-limit":   {path: "/json", body: `{"name":"a"}`, expectedCode: http.StatusOK},
+		"content-length": {path: "/json", body: `{"name":"mock-long-name"}`, expectedCode: http.StatusRequestEntityTooLarge},
+		"chunked":        {path: "/json", body: `{"name":"mock-long-name"}`, chunked: true, expectedCode: http.StatusRequestEntityTooLarge},
+		"route-limit":    {path: "/raw", body: "123456", chunked: true, expectedCode: http.StatusRequestEntityTooLarge},
+		"route-within":   {path: "/raw", body: "12345", chunked: true, expectedCode: http.StatusOK},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body))
+			req.Header.Set(HeaderContentType, "application/json")
+			if tc.chunked {
+				req.ContentLength = -1
+			}
+
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, req)
+			require.Equal(t, tc.expectedCode, w.Code)
+			if tc.expectedCode == http.StatusRequestEntityTooLarge {
+				require.Contains(t, w.Body.String(), "Request Entity Too Large")
+			}
+		})
+	}
+}
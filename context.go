@@ -14,6 +14,8 @@ const (
 	ctxKeyHTTPResponseWriter
 	ctxKeyApp
 	ctxKeyRoute
+	ctxKeyParams
+	ctxKeyLastEventID
 )
 
 // ResponseHeaderFromCtx returns Header for HTTP response which will be sent.
@@ -27,6 +29,13 @@ func ResponseHeaderFromCtx(ctx context.Context) http.Header {
 	return nil
 }
 
+// ParamsFromCtx returns path params of the request being served.
+// The function returns nil if there is no params.
+func ParamsFromCtx(ctx context.Context) httprouter.Params {
+	params, _ := ctx.Value(ctxKeyParams).(httprouter.Params)
+	return params
+}
+
 func contextInjector() OptionFn {
 	return func(app *Application) {
 		var routeOpt RouteOptionFn = func(r *route) {
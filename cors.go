@@ -24,6 +24,8 @@ var DefaultCORSConfig = CORSConfig{
 }
 
 // WithCORS returns a middleware to support Cross-Origin Resource Sharing.
+// When it's applied to the application, preflight requests are answered for all registered paths
+// as OPTIONS requests are handled by application-level middlewares.
 func WithCORS(cfg CORSConfig) Middleware {
 	return func(next Handler) Handler {
 		return func(ctx context.Context, req Request) (interface{}, error) {
@@ -166,3 +168,9 @@ func getAllowOrigin(origin string, cfg CORSConfig) string {
 
 	return ""
 }
+
+// preflightHandler answers OPTIONS requests for paths without OPTIONS routes.
+// Application-level middlewares are applied to it, so preflight headers are added by WithCORS if it's applied to the application.
+func preflightHandler(_ context.Context, _ Request) (interface{}, error) {
+	return nil, nil
+}
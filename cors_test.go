@@ -144,3 +144,55 @@ func Test_CORS_allowOriginScheme(t *testing.T) {
 	require.NoError(t, err)
 	require.Empty(t, rr.Header().Get(HeaderAccessControlAllowOrigin))
 }
+
+func Test_CORS_preflightForAllPaths(t *testing.T) {
+	app := New(WithCORS(CORSConfig{
+		AllowOrigins: []string{"localhost"},
+		AllowMethods: []string{http.MethodGet, http.MethodPost},
+	}))
+	app.GET("/users/:id", mockHandler)
+	app.POST("/users/:id", mockHandler)
+
+	req := httptest.NewRequest(http.MethodOptions, "/users/1", nil)
+	req.Header.Set(HeaderOrigin, "localhost")
+	req.Header.Set(HeaderAccessControlRequestMethod, http.MethodPost)
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusNoContent, rr.Code)
+	require.Equal(t, "localhost", rr.Header().Get(HeaderAccessControlAllowOrigin))
+	require.Equal(t, "GET,POST", rr.Header().Get(HeaderAccessControlAllowMethods))
+	require.Equal(t, "GET, OPTIONS, POST", rr.Header().Get("Allow"))
+}
+
+func Test_CORS_preflightWithoutAppLevelCORS(t *testing.T) {
+	app := New()
+	app.GET("/users/:id", mockHandler, WithCORS(DefaultCORSConfig))
+
+	req := httptest.NewRequest(http.MethodOptions, "/users/1", nil)
+	req.Header.Set(HeaderOrigin, "localhost")
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusNoContent, rr.Code)
+	require.Equal(t, "GET, OPTIONS", rr.Header().Get("Allow"))
+	require.Empty(t, rr.Header().Get(HeaderAccessControlAllowOrigin))
+}
+
+func Test_CORS_preflightWithWrappedCORS(t *testing.T) {
+	cors := WithCORS(DefaultCORSConfig)
+	var wrapped Middleware = func(next Handler) Handler {
+		return cors(next)
+	}
+
+	app := New(wrapped)
+	app.GET("/users/:id", mockHandler)
+
+	req := httptest.NewRequest(http.MethodOptions, "/users/1", nil)
+	req.Header.Set(HeaderOrigin, "localhost")
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusNoContent, rr.Code)
+	require.Equal(t, "*", rr.Header().Get(HeaderAccessControlAllowOrigin))
+}
@@ -1,6 +1,8 @@
 package mysql
 
 import (
+	"context"
+
 	"github.com/bongnv/nanny"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -20,6 +22,7 @@ type Config struct {
 }
 
 // WithMYSQL intializes an MySQL instance and registers it to the Application.
+// The connection is checked by the readiness endpoint and closed when the Application stops.
 func WithMYSQL(cfg Config) nanny.OptionFn {
 	return func(app *nanny.Application) {
 		gormCfg := mysql.Config{
@@ -38,6 +41,22 @@ func WithMYSQL(cfg Config) nanny.OptionFn {
 		}
 
 		app.MustRegister("db", db)
+		app.AddHealthCheck("db", func(ctx context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+
+			return sqlDB.PingContext(ctx)
+		})
+		app.OnStop(func(_ context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+
+			return sqlDB.Close()
+		})
 	}
 }
 
@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"context"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -22,6 +23,26 @@ func Test_WithMYSQL(t *testing.T) {
 	require.IsType(t, &gorm.DB{}, component)
 }
 
+func Test_WithMYSQL_closeOnStop(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	rows := sqlmock.NewRows([]string{"VERSION()"}).AddRow("5.6")
+	mock.ExpectQuery("SELECT VERSION()").WillReturnRows(rows)
+	mock.ExpectClose()
+	app := nanny.New(
+		nanny.WithAddress("127.0.0.1:0"),
+		nanny.WithShutdownSignals(),
+		WithMYSQL(Config{
+			Conn: db,
+		}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, app.RunContext(ctx))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func Test_WithMYSQLByDSN_panic(t *testing.T) {
 	require.Panics(t, func() {
 		_ = nanny.New(WithMYSQLByDSN(""))
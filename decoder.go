@@ -1,10 +1,8 @@
 package nanny
 
 import (
-	"encoding/json"
 	"net/http"
-
-	"github.com/gorilla/schema"
+	"reflect"
 )
 
 // Decoder defines a request decoder.
@@ -22,27 +20,68 @@ func WithDecoder(d Decoder) RouteOptionFn {
 
 func newDecoder() Decoder {
 	return &defaultDecoder{
-		schemaDecoder: schema.NewDecoder(),
+		bodyDecoders: defaultBodyDecoders(),
 	}
 }
 
+// defaultDecoder binds values to fields of a struct from struct tags.
+// Values are bound in the order below, later sources take precedence over earlier ones:
+// default tags, form values for fields without source tags, the body, cookie, header, query and path.
+// The body is decoded by the BodyDecoderFunc of its media type, unknown media types are responded with 415 status code
+// and malformed bodies with 400 status code.
+// Errors of all fields are collected and returned as ParamErrors.
 type defaultDecoder struct {
-	schemaDecoder *schema.Decoder
+	bodyDecoders map[string]BodyDecoderFunc
 }
 
 func (d *defaultDecoder) Decode(obj interface{}, req *http.Request) error {
-	if err := d.schemaDecoder.Decode(obj, req.Form); err != nil {
+	mediaType, err := parseMediaType(req)
+	if err != nil {
 		return err
 	}
 
-	contentType := req.Header.Get("Content-Type")
-	if contentType == "application/json" && req.ContentLength > 0 {
-		// erase all values in forms so that they won't overwrite parsed json values
-		jsonDecoder := json.NewDecoder(req.Body)
-		if err := jsonDecoder.Decode(obj); err != nil {
+	var decodeBody BodyDecoderFunc
+	if mediaType != "" && hasBody(req) {
+		if decodeBody = d.bodyDecoder(mediaType); decodeBody == nil {
+			return unsupportedMediaTypeErr
+		}
+	}
+
+	if mediaType == mediaTypeMultipart {
+		if err := parseMultipartForm(req, DefaultMultipartConfig); err != nil {
+			return err
+		}
+	}
+
+	v := reflect.ValueOf(obj)
+	isStruct := v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.Struct
+
+	var fields []*bindingField
+	var errs ParamErrors
+	if isStruct {
+		v = v.Elem()
+		fields = getBindingFields(v.Type())
+		errs = append(errs, bindDefaults(v, fields)...)
+		errs = append(errs, bindForm(v, fields, req)...)
+	}
+
+	if decodeBody != nil {
+		if err := decodeBody(req.Body, obj); err != nil {
+			if isBodyTooLarge(req.Body) {
+				return requestTooLargeErr
+			}
+
 			return err
 		}
 	}
 
+	if isStruct {
+		errs = append(errs, bindSources(v, fields, req)...)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
 	return nil
 }
@@ -1,7 +1,8 @@
 package nanny
 
 import (
-	"encoding/json"
+	"bytes"
+	"fmt"
 	"net/http"
 )
 
@@ -15,6 +16,14 @@ type Encoder interface {
 	Encode(w http.ResponseWriter, resp interface{}) error
 }
 
+// RequestEncoder defines an Encoder which encodes responses depending on the request, e.g. for content negotiation.
+// EncodeRequest is called instead of Encode if the Encoder of a route implements it.
+type RequestEncoder interface {
+	Encoder
+	// EncodeRequest encodes resp for req and writes to http.ResponseWriter.
+	EncodeRequest(w http.ResponseWriter, req *http.Request, resp interface{}) error
+}
+
 // WithEncoder specifies the encoder which will be used to encode payload to HTTP response.
 func WithEncoder(e Encoder) RouteOptionFn {
 	return func(r *route) {
@@ -22,9 +31,36 @@ func WithEncoder(e Encoder) RouteOptionFn {
 	}
 }
 
-type defaultEncoder struct{}
+func newEncoder() Encoder {
+	return defaultEncoder{
+		encoders: defaultResponseEncoders(),
+	}
+}
+
+// defaultEncoder encodes responses with the media type negotiated from the Accept header.
+// JSON is used if there is no Accept header and 406 status code is responded if no media type is acceptable.
+// If encoding fails, the next acceptable media type is tried and 500 status code is responded if none succeeds.
+// A nil response is responded with 204 status code, a CustomHTTPResponse writes itself
+// and the Body of a Response is encoded with its status code, headers and cookies.
+type defaultEncoder struct {
+	encoders []responseEncoder
+}
 
 func (e defaultEncoder) Encode(w http.ResponseWriter, resp interface{}) error {
+	return e.EncodeRequest(w, nil, resp)
+}
+
+func (e defaultEncoder) EncodeRequest(w http.ResponseWriter, req *http.Request, resp interface{}) error {
+	status := http.StatusOK
+	if r, ok := asResponse(resp); ok {
+		status = r.writeHeader(w)
+		resp = r.Body
+		if resp == nil {
+			w.WriteHeader(status)
+			return nil
+		}
+	}
+
 	if resp == nil {
 		w.WriteHeader(http.StatusNoContent)
 		return nil
@@ -35,7 +71,43 @@ func (e defaultEncoder) Encode(w http.ResponseWriter, resp interface{}) error {
 		return nil
 	}
 
-	w.Header().Add(HeaderContentType, jsonScheme)
-	enc := json.NewEncoder(w)
-	return enc.Encode(resp)
+	encoders := e.encoders
+	if len(encoders) == 0 {
+		encoders = []responseEncoder{{mediaType: jsonScheme, encode: encodeJSON}}
+	}
+
+	accept := ""
+	if req != nil {
+		accept = req.Header.Get(HeaderAccept)
+		w.Header().Add(HeaderVary, HeaderAccept)
+	}
+
+	// an encoder which fails is skipped, so the next acceptable media type is tried
+	var encodeErr error
+	buf := &bytes.Buffer{}
+	for _, enc := range negotiate(accept, encoders) {
+		buf.Reset()
+		err := enc.encode(buf, resp)
+		if err == ErrUnsupportedResponse {
+			continue
+		}
+
+		if err != nil {
+			encodeErr = fmt.Errorf("nanny: error when encoding response as %s: %w", enc.mediaType, err)
+			continue
+		}
+
+		w.Header().Set(HeaderContentType, enc.mediaType)
+		w.WriteHeader(status)
+		_, err = w.Write(buf.Bytes())
+		return err
+	}
+
+	if encodeErr != nil {
+		encodingErr.WriteTo(w)
+		return encodeErr
+	}
+
+	notAcceptableErr.WriteTo(w)
+	return nil
 }
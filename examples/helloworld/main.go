@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"log"
 
 	"github.com/bongnv/nanny"
 )
@@ -22,5 +23,7 @@ func main() {
 		return "Hello " + reqDto.Name, nil
 	})
 
-	app.Run()
+	if err := app.Run(); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -1,13 +1,15 @@
 module github.com/bongnv/nanny
 
-go 1.13
+go 1.16
 
 require (
 	github.com/DATA-DOG/go-sqlmock v1.5.0
 	github.com/bongnv/inject v1.0.0
-	github.com/gorilla/schema v1.2.0
 	github.com/julienschmidt/httprouter v1.3.0
 	github.com/stretchr/testify v1.6.1
+	github.com/vmihailenco/msgpack/v5 v5.3.5
+	golang.org/x/net v0.0.0-20201110031124-69a78807bb2b
+	google.golang.org/protobuf v1.28.1
 	gorm.io/driver/mysql v1.0.3
 	gorm.io/gorm v1.20.5
 )
@@ -28,16 +28,17 @@ var (
 )
 
 // WithGzip returns a middleware which compresses HTTP response using gzip compression.
+// Responses of Server-Sent Events routes aren't compressed.
 func WithGzip(cfg GzipConfig) RouteOptionFn {
 	return func(r *route) {
-		r.transformers = append(r.transformers, gzipTransformer(cfg))
+		r.addTransformer("nanny.WithGzip", gzipTransformer(cfg))
 	}
 }
 
 func gzipTransformer(cfg GzipConfig) handleTransformer {
 	return func(next httprouter.Handle) httprouter.Handle {
 		return func(rw http.ResponseWriter, req *http.Request, params httprouter.Params) {
-			if !strings.Contains(req.Header.Get(HeaderAcceptEncoding), gzipScheme) {
+			if !strings.Contains(req.Header.Get(HeaderAcceptEncoding), gzipScheme) || isSSERoute(req.Context()) {
 				next(rw, req, params)
 				return
 			}
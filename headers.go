@@ -2,6 +2,7 @@ package nanny
 
 // Headers
 const (
+	HeaderAccept                        = "Accept"
 	HeaderAcceptEncoding                = "Accept-Encoding"
 	HeaderAccessControlAllowCredentials = "Access-Control-Allow-Credentials"
 	HeaderAccessControlAllowHeaders     = "Access-Control-Allow-Headers"
@@ -10,9 +11,12 @@ const (
 	HeaderAccessControlMaxAge           = "Access-Control-Max-Age"
 	HeaderAccessControlRequestHeaders   = "Access-Control-Request-Headers"
 	HeaderAccessControlRequestMethod    = "Access-Control-Request-Method"
+	HeaderCacheControl                  = "Cache-Control"
 	HeaderContentEncoding               = "Content-Encoding"
 	HeaderContentLength                 = "Content-Length"
 	HeaderContentType                   = "Content-Type"
+	HeaderLastEventID                   = "Last-Event-ID"
+	HeaderLocation                      = "Location"
 	HeaderOrigin                        = "Origin"
 	HeaderVary                          = "Vary"
 )
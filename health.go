@@ -0,0 +1,147 @@
+package nanny
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	healthStatusOK   = "ok"
+	healthStatusFail = "fail"
+)
+
+// HealthCheckConfig defines the config for WithHealthCheck.
+type HealthCheckConfig struct {
+	// LivenessPath is the path of the liveness endpoint.
+	LivenessPath string
+	// ReadinessPath is the path of the readiness endpoint.
+	ReadinessPath string
+}
+
+// DefaultHealthCheckConfig is the default config for WithHealthCheck.
+var DefaultHealthCheckConfig = HealthCheckConfig{
+	LivenessPath:  "/healthz",
+	ReadinessPath: "/readyz",
+}
+
+// HealthChecker checks whether a dependency of the application is healthy.
+type HealthChecker func(ctx context.Context) error
+
+// WithHealthCheck registers liveness and readiness endpoints.
+// The readiness endpoint runs all checkers registered via AddHealthCheck and
+// fails before the application serves requests or as soon as it starts shutting down.
+func WithHealthCheck(cfg HealthCheckConfig) OptionFn {
+	return func(app *Application) {
+		app.healthCheck = &cfg
+	}
+}
+
+// AddHealthCheck registers a named checker which is run by the readiness endpoint.
+func (app *Application) AddHealthCheck(name string, checker HealthChecker) {
+	app.healthCheckers = append(app.healthCheckers, namedHealthChecker{
+		name:    name,
+		checker: checker,
+	})
+}
+
+type namedHealthChecker struct {
+	name    string
+	checker HealthChecker
+}
+
+type healthReport struct {
+	Status string                       `json:"status"`
+	Reason string                       `json:"reason,omitempty"`
+	Checks map[string]healthCheckResult `json:"checks,omitempty"`
+}
+
+type healthCheckResult struct {
+	Status  string `json:"status"`
+	Latency string `json:"latency"`
+	Error   string `json:"error,omitempty"`
+}
+
+// WriteTo implements CustomHTTPResponse.
+func (report healthReport) WriteTo(w http.ResponseWriter) {
+	code := http.StatusOK
+	if report.Status != healthStatusOK {
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set(HeaderContentType, jsonScheme)
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+func (app *Application) registerHealthCheck() {
+	cfg := app.healthCheck
+	if cfg.LivenessPath != "" {
+		app.GET(cfg.LivenessPath, app.livenessHandler)
+	}
+
+	if cfg.ReadinessPath != "" {
+		app.GET(cfg.ReadinessPath, app.readinessHandler)
+	}
+}
+
+func (app *Application) livenessHandler(_ context.Context, _ Request) (interface{}, error) {
+	return healthReport{Status: healthStatusOK}, nil
+}
+
+func (app *Application) readinessHandler(ctx context.Context, _ Request) (interface{}, error) {
+	select {
+	case <-app.shutdownSignal:
+		return healthReport{Status: healthStatusFail, Reason: "shutting down"}, nil
+	default:
+	}
+
+	select {
+	case <-app.readyCh:
+	default:
+		return healthReport{Status: healthStatusFail, Reason: "not started"}, nil
+	}
+
+	return app.runHealthCheckers(ctx), nil
+}
+
+// runHealthCheckers runs all checkers concurrently and reports their results.
+func (app *Application) runHealthCheckers(ctx context.Context) healthReport {
+	report := healthReport{
+		Status: healthStatusOK,
+		Checks: make(map[string]healthCheckResult, len(app.healthCheckers)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range app.healthCheckers {
+		wg.Add(1)
+		go func(c namedHealthChecker) {
+			defer wg.Done()
+
+			startedAt := time.Now()
+			err := c.checker(ctx)
+			result := healthCheckResult{
+				Status:  healthStatusOK,
+				Latency: time.Since(startedAt).String(),
+			}
+
+			if err != nil {
+				result.Status = healthStatusFail
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[c.name] = result
+			if err != nil {
+				report.Status = healthStatusFail
+			}
+		}(c)
+	}
+
+	wg.Wait()
+	return report
+}
@@ -0,0 +1,97 @@
+package nanny
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithHealthCheck(t *testing.T) {
+	app := New(WithHealthCheck(DefaultHealthCheckConfig))
+	app.AddHealthCheck("ok-check", func(_ context.Context) error {
+		return nil
+	})
+	// simulate Run before listeners are ready
+	app.running = true
+
+	t.Run("liveness", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+		resp := executeRequest(app, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.JSONEq(t, `{"status":"ok"}`, resp.Body.String())
+	})
+
+	t.Run("not-started", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		resp := executeRequest(app, req)
+		require.Equal(t, http.StatusServiceUnavailable, resp.Code)
+		require.JSONEq(t, `{"status":"fail","reason":"not started"}`, resp.Body.String())
+	})
+
+	app.markReady()
+	t.Run("ready", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		resp := executeRequest(app, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		report := healthReport{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &report))
+		require.Equal(t, "ok", report.Status)
+		require.Equal(t, "ok", report.Checks["ok-check"].Status)
+		require.NotEmpty(t, report.Checks["ok-check"].Latency)
+	})
+
+	app.AddHealthCheck("failed-check", func(_ context.Context) error {
+		return errors.New("connection refused")
+	})
+	t.Run("failed-check", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		resp := executeRequest(app, req)
+		require.Equal(t, http.StatusServiceUnavailable, resp.Code)
+
+		report := healthReport{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &report))
+		require.Equal(t, "fail", report.Status)
+		require.Equal(t, "ok", report.Checks["ok-check"].Status)
+		require.Equal(t, "fail", report.Checks["failed-check"].Status)
+		require.Equal(t, "connection refused", report.Checks["failed-check"].Error)
+	})
+
+	app.shutdown()
+	t.Run("shutting-down", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		resp := executeRequest(app, req)
+		require.Equal(t, http.StatusServiceUnavailable, resp.Code)
+		require.JSONEq(t, `{"status":"fail","reason":"shutting down"}`, resp.Body.String())
+	})
+}
+
+func Test_WithHealthCheck_customPaths(t *testing.T) {
+	app := New(WithHealthCheck(HealthCheckConfig{
+		ReadinessPath: "/ready",
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/ready", nil)
+	resp := executeRequest(app, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	resp = executeRequest(app, req)
+	require.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func Test_WithHealthCheck_servedOutsideRun(t *testing.T) {
+	app := New(WithHealthCheck(DefaultHealthCheckConfig))
+	srv := httptest.NewServer(app)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/readyz")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -28,4 +28,8 @@ func (err HTTPError) Error() string {
 var (
 	timeoutErr = HTTPError{Code: http.StatusInternalServerError, Message: "Request Timeout"}
 	panicErr   = HTTPError{Code: http.StatusServiceUnavailable, Message: "Service Unavailable"}
+
+	notFoundErr         = HTTPError{Code: http.StatusNotFound, Message: "Not Found"}
+	methodNotAllowedErr = HTTPError{Code: http.StatusMethodNotAllowed, Message: "Method Not Allowed"}
+	requestTooLargeErr  = HTTPError{Code: http.StatusRequestEntityTooLarge, Message: "Request Entity Too Large"}
 )
@@ -0,0 +1,87 @@
+package nanny
+
+import (
+	"context"
+	"fmt"
+)
+
+// Starter is implemented by components which need to be started before the application serves requests.
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// Stopper is implemented by components which need to be stopped after the HTTP server is drained.
+type Stopper interface {
+	Stop(ctx context.Context) error
+}
+
+// Hook defines a function which is called during the lifecycle of the application.
+type Hook func(ctx context.Context) error
+
+// OnStart registers a hook which is called before the application serves requests.
+// Hooks are called in the order they are registered.
+func (app *Application) OnStart(h Hook) {
+	app.lifecycle = append(app.lifecycle, lifecycleHook{onStart: h})
+}
+
+// OnStop registers a hook which is called after the HTTP server is drained.
+// Hooks are called in the reverse order they are registered.
+func (app *Application) OnStop(h Hook) {
+	app.lifecycle = append(app.lifecycle, lifecycleHook{onStop: h})
+}
+
+type lifecycleHook struct {
+	onStart Hook
+	onStop  Hook
+}
+
+func (app *Application) registerLifecycle(component interface{}) {
+	h := lifecycleHook{}
+	if s, ok := component.(Starter); ok {
+		h.onStart = s.Start
+	}
+
+	if s, ok := component.(Stopper); ok {
+		h.onStop = s.Stop
+	}
+
+	if h.onStart != nil || h.onStop != nil {
+		app.lifecycle = append(app.lifecycle, h)
+	}
+}
+
+// start calls start hooks in order. If a hook fails, hooks which have been started are stopped.
+func (app *Application) start(ctx context.Context) error {
+	for i, h := range app.lifecycle {
+		if h.onStart == nil {
+			continue
+		}
+
+		if err := h.onStart(ctx); err != nil {
+			app.stop(app.lifecycle[:i])
+			return fmt.Errorf("nanny: error when starting components: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// stop calls stop hooks in the reverse order.
+func (app *Application) stop(hooks []lifecycleHook) {
+	ctx := context.Background()
+	if app.shutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, app.shutdownTimeout)
+		defer cancel()
+	}
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if hooks[i].onStop == nil {
+			continue
+		}
+
+		if err := hooks[i].onStop(ctx); err != nil {
+			app.fail(fmt.Errorf("nanny: error when stopping components: %w", err))
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package nanny
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockComponent struct {
+	name     string
+	calls    *[]string
+	startErr error
+}
+
+func (c *mockComponent) Start(_ context.Context) error {
+	*c.calls = append(*c.calls, "start "+c.name)
+	return c.startErr
+}
+
+func (c *mockComponent) Stop(_ context.Context) error {
+	*c.calls = append(*c.calls, "stop "+c.name)
+	return nil
+}
+
+func Test_lifecycle(t *testing.T) {
+	var calls []string
+	app := New(WithAddress("127.0.0.1:0"), WithShutdownSignals())
+	app.MustRegister("a", &mockComponent{name: "a", calls: &calls})
+	app.OnStart(func(_ context.Context) error {
+		calls = append(calls, "start hook")
+		return nil
+	})
+	app.OnStop(func(_ context.Context) error {
+		calls = append(calls, "stop hook")
+		return nil
+	})
+	app.MustRegister("b", &mockComponent{name: "b", calls: &calls})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	app.OnStart(func(_ context.Context) error {
+		cancel()
+		return nil
+	})
+
+	require.NoError(t, app.RunContext(ctx))
+	require.Equal(t, []string{
+		"start a",
+		"start hook",
+		"start b",
+		"stop b",
+		"stop hook",
+		"stop a",
+	}, calls)
+}
+
+func Test_lifecycle_startError(t *testing.T) {
+	var calls []string
+	app := New()
+	app.MustRegister("a", &mockComponent{name: "a", calls: &calls})
+	app.MustRegister("b", &mockComponent{name: "b", calls: &calls, startErr: errors.New("start error")})
+	app.MustRegister("c", &mockComponent{name: "c", calls: &calls})
+
+	err := app.Run()
+	require.EqualError(t, err, "nanny: error when starting components: start error")
+	require.Equal(t, []string{"start a", "start b", "stop a"}, calls)
+}
+
+func Test_lifecycle_stopError(t *testing.T) {
+	app := New(WithAddress("127.0.0.1:0"), WithShutdownSignals())
+	app.OnStop(func(_ context.Context) error {
+		return errors.New("stop error")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := app.RunContext(ctx)
+	require.EqualError(t, err, "nanny: error when stopping components: stop error")
+}
@@ -0,0 +1,112 @@
+package nanny
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultAddr = ":8080"
+	unixScheme  = "unix://"
+
+	// listenFdsStart is the first file descriptor passed by systemd socket activation.
+	listenFdsStart = 3
+)
+
+// WithListener specifies a listener for the server to accept connections from.
+// It can be used multiple times to serve on several listeners.
+func WithListener(ln net.Listener) OptionFn {
+	return func(app *Application) {
+		app.listeners = append(app.listeners, ln)
+	}
+}
+
+// WithSocketActivation serves on listeners passed by systemd socket activation via LISTEN_FDS.
+func WithSocketActivation() OptionFn {
+	return func(app *Application) {
+		app.socketActivation = true
+	}
+}
+
+// listen opens all listeners which the server will accept connections from.
+// If there is no address nor listener specified, the server listens on :8080.
+func (app *Application) listen() ([]net.Listener, error) {
+	listeners := append([]net.Listener{}, app.listeners...)
+	if app.socketActivation {
+		activated, err := socketActivationListeners()
+		if err != nil {
+			return nil, err
+		}
+
+		listeners = append(listeners, activated...)
+	}
+
+	addrs := app.addrs
+	if len(addrs) == 0 && len(listeners) == 0 {
+		addrs = []string{defaultAddr}
+	}
+
+	for _, addr := range addrs {
+		ln, err := listenOn(addr)
+		if err != nil {
+			closeListeners(listeners)
+			return nil, fmt.Errorf("nanny: error when listening on %s: %w", addr, err)
+		}
+
+		listeners = append(listeners, ln)
+	}
+
+	return listeners, nil
+}
+
+// listenOn listens on a TCP address or a Unix socket if the address is in the form of unix:///path/to/socket.
+func listenOn(addr string) (net.Listener, error) {
+	if strings.HasPrefix(addr, unixScheme) {
+		return net.Listen("unix", strings.TrimPrefix(addr, unixScheme))
+	}
+
+	return net.Listen("tcp", addr)
+}
+
+// socketActivationListeners returns listeners from file descriptors passed by systemd.
+// Ref: https://www.freedesktop.org/software/systemd/man/sd_listen_fds.html
+func socketActivationListeners() ([]net.Listener, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, fmt.Errorf("nanny: no socket is passed by socket activation")
+	}
+
+	nfds, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || nfds <= 0 {
+		return nil, fmt.Errorf("nanny: no socket is passed by socket activation")
+	}
+
+	// unset the variables so that they won't be inherited by child processes.
+	_ = os.Unsetenv("LISTEN_PID")
+	_ = os.Unsetenv("LISTEN_FDS")
+	_ = os.Unsetenv("LISTEN_FDNAMES")
+
+	listeners := make([]net.Listener, 0, nfds)
+	for fd := listenFdsStart; fd < listenFdsStart+nfds; fd++ {
+		f := os.NewFile(uintptr(fd), "LISTEN_FD_"+strconv.Itoa(fd))
+		ln, err := net.FileListener(f)
+		_ = f.Close()
+		if err != nil {
+			closeListeners(listeners)
+			return nil, fmt.Errorf("nanny: error when using socket %d from socket activation: %w", fd, err)
+		}
+
+		listeners = append(listeners, ln)
+	}
+
+	return listeners, nil
+}
+
+func closeListeners(listeners []net.Listener) {
+	for _, ln := range listeners {
+		_ = ln.Close()
+	}
+}
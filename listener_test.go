@@ -0,0 +1,93 @@
+package nanny
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newMockApp(opts ...Option) *Application {
+	app := New(append([]Option{WithShutdownSignals()}, opts...)...)
+	app.GET("/mock-endpoint", func(ctx context.Context, req Request) (interface{}, error) {
+		return "OK", nil
+	})
+
+	return app
+}
+
+func Test_WithListener(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	app := newMockApp(WithListener(ln))
+	defer runApp(t, app)()
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/mock-endpoint")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func Test_WithAddress_multiple(t *testing.T) {
+	addr1, addr2 := freeAddr(t), freeAddr(t)
+	app := newMockApp(WithAddress(addr1, addr2))
+	defer runApp(t, app)()
+
+	for _, addr := range []string{addr1, addr2} {
+		resp, err := http.Get("http://" + addr + "/mock-endpoint")
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+}
+
+func Test_WithAddress_unixSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nanny-unix")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	sockPath := filepath.Join(dir, "app.sock")
+	app := newMockApp(WithAddress("unix://" + sockPath))
+	defer runApp(t, app)()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", sockPath)
+			},
+		},
+	}
+	resp, err := client.Get("http://unix/mock-endpoint")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func Test_WithAddress_listenError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	freeLn, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	app := newMockApp(WithListener(freeLn), WithAddress(ln.Addr().String()))
+	err = app.Run()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error when listening on "+ln.Addr().String())
+
+	_, err = freeLn.Accept()
+	require.Error(t, err, "listeners must be closed")
+}
+
+func Test_WithSocketActivation_noSocket(t *testing.T) {
+	app := newMockApp(WithSocketActivation())
+	err := app.Run()
+	require.EqualError(t, err, "nanny: no socket is passed by socket activation")
+}
@@ -3,9 +3,9 @@ package nanny
 // Middleware defines a middleware to provide additional logic.
 type Middleware func(Handler) Handler
 
-// ApplyRoute implements RouteOption.
+// ApplyRoute implements RouteOption. The middleware is named after the function which creates it in Routes.
 func (m Middleware) ApplyRoute(r *route) {
-	r.middlewares = append(r.middlewares, m)
+	r.addMiddleware(funcName(m), m)
 }
 
 // Apply implements Option.
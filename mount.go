@@ -0,0 +1,71 @@
+package nanny
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const mountPathParam = "path"
+
+// Mount serves all requests under prefix with an http.Handler for all HTTP methods.
+// The prefix is stripped from the path of requests like http.StripPrefix.
+// Route options of the group are applied to the mounted handler.
+func (g *RouteGroup) Mount(prefix string, h http.Handler, opts ...RouteOption) {
+	prefix = strings.TrimSuffix(prefix, "/")
+	mh := mountedHandler(h)
+	// routes of the mount are considered a single route, e.g. when checking duplicate names
+	opts = append(opts[:len(opts):len(opts)], RouteOptionFn(func(r *route) {
+		r.mount = prefix + "/"
+	}))
+	if prefix != "" {
+		g.Any(prefix, mh, opts...)
+	}
+
+	g.Any(prefix+"/*"+mountPathParam, mh, opts...)
+}
+
+// WithHTTPMiddleware adapts a standard net/http middleware to a RouteOption.
+func WithHTTPMiddleware(m func(http.Handler) http.Handler) RouteOptionFn {
+	return func(r *route) {
+		var t handleTransformer = func(next httprouter.Handle) httprouter.Handle {
+			return func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
+				m(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					next(w, req, params)
+				})).ServeHTTP(w, req)
+			}
+		}
+
+		r.addTransformer("nanny.WithHTTPMiddleware", t)
+	}
+}
+
+func mountedHandler(h http.Handler) Handler {
+	return func(ctx context.Context, req Request) (interface{}, error) {
+		httpReq := req.HTTPRequest()
+		stripped := httpReq.WithContext(ctx)
+		stripped.URL = &url.URL{}
+		*stripped.URL = *httpReq.URL
+		stripped.URL.Path = "/" + strings.TrimPrefix(req.Param(mountPathParam), "/")
+		stripped.URL.RawPath = ""
+
+		return &httpHandlerResponse{
+			handler: h,
+			httpReq: stripped,
+		}, nil
+	}
+}
+
+// httpHandlerResponse is a CustomHTTPResponse to serve a request by an http.Handler.
+type httpHandlerResponse struct {
+	handler http.Handler
+	httpReq *http.Request
+}
+
+// WriteTo implements CustomHTTPResponse.
+func (resp *httpHandlerResponse) WriteTo(w http.ResponseWriter) {
+	resp.handler.ServeHTTP(w, resp.httpReq)
+}
@@ -0,0 +1,73 @@
+package nanny
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Mount(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(req.Method + " " + req.URL.Path))
+	})
+
+	app := New(WithCORS(DefaultCORSConfig))
+	app.GET("/users", mockHandler)
+	app.Group("/admin").Mount("/ui", mux)
+
+	tcs := map[string]struct {
+		method string
+		target string
+		body   string
+	}{
+		"prefix":    {method: http.MethodGet, target: "/admin/ui", body: "GET /"},
+		"root":      {method: http.MethodGet, target: "/admin/ui/", body: "GET /"},
+		"sub-path":  {method: http.MethodPost, target: "/admin/ui/users/1?q=1", body: "POST /users/1"},
+		"other-put": {method: http.MethodPut, target: "/admin/ui/settings", body: "PUT /settings"},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, httptest.NewRequest(tc.method, tc.target, nil))
+			require.Equal(t, http.StatusOK, rr.Code)
+			require.Equal(t, tc.body, rr.Body.String())
+			require.Equal(t, "*", rr.Header().Get(HeaderAccessControlAllowOrigin), "group options must be applied")
+		})
+	}
+}
+
+func Test_WithHTTPMiddleware(t *testing.T) {
+	m := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.Header.Get("Authorization") == "" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			w.Header().Set("X-Authorized", "true")
+			next.ServeHTTP(w, req)
+		})
+	}
+
+	app := New(WithHTTPMiddleware(m))
+	app.GET("/users/:id", func(ctx context.Context, req Request) (interface{}, error) {
+		return req.Param("id"), nil
+	})
+
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rr = httptest.NewRecorder()
+	app.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "true", rr.Header().Get("X-Authorized"))
+	require.Equal(t, "\"1\"\n", rr.Body.String())
+}
@@ -0,0 +1,247 @@
+package nanny
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+)
+
+// MultipartConfig defines limits of multipart requests.
+type MultipartConfig struct {
+	// MaxMemory is the maximum bytes of files stored in memory, the rest is stored in temporary files.
+	MaxMemory int64
+	// MaxSize limits the total size of a multipart body.
+	// Optional. Default value 0 means no limit.
+	MaxSize int64
+	// MaxFileSize limits the size of each file.
+	// Optional. Default value 0 means no limit.
+	MaxFileSize int64
+	// MaxFiles limits the number of files.
+	// Optional. Default value 0 means no limit.
+	MaxFiles int
+}
+
+// DefaultMultipartConfig is the default config for multipart requests.
+var DefaultMultipartConfig = MultipartConfig{
+	MaxMemory: 32 << 20,
+}
+
+// errors of multipart requests.
+var (
+	fileTooLargeErr     = HTTPError{Code: http.StatusRequestEntityTooLarge, Message: "File Too Large"}
+	tooManyFilesErr     = HTTPError{Code: http.StatusRequestEntityTooLarge, Message: "Too Many Files"}
+	invalidMultipartErr = HTTPError{Code: http.StatusBadRequest, Message: "Invalid Multipart Body"}
+)
+
+// WithMultipartConfig specifies limits of multipart requests. Requests exceeding limits are responded with 413 status code.
+func WithMultipartConfig(cfg MultipartConfig) RouteOptionFn {
+	return func(r *route) {
+		r.multipartConfig = cfg
+	}
+}
+
+// limitedReader returns requestTooLargeErr when more than n bytes are read.
+type limitedReader struct {
+	io.ReadCloser
+	n        int64
+	exceeded bool
+}
+
+func newLimitedReader(r io.ReadCloser, n int64) *limitedReader {
+	return &limitedReader{ReadCloser: r, n: n}
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.exceeded {
+		return 0, requestTooLargeErr
+	}
+
+	// read one more byte to detect if the body exceeds the limit
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+
+	n, err := l.ReadCloser.Read(p)
+	if int64(n) <= l.n {
+		l.n -= int64(n)
+		return n, err
+	}
+
+	n = int(l.n)
+	l.n = 0
+	l.exceeded = true
+	return n, requestTooLargeErr
+}
+
+// multipartReader returns a multipart reader of the request body limited by MaxSize.
+func multipartReader(req *http.Request, cfg MultipartConfig) (*multipart.Reader, error) {
+	if cfg.MaxSize > 0 && req.ContentLength > cfg.MaxSize {
+		return nil, requestTooLargeErr
+	}
+
+	if cfg.MaxSize > 0 {
+		req.Body = newLimitedReader(req.Body, cfg.MaxSize)
+	}
+
+	mr, err := req.MultipartReader()
+	if err != nil {
+		return nil, invalidMultipartErr
+	}
+
+	return mr, nil
+}
+
+// parseMultipartForm parses a multipart body and checks its limits.
+// Values of the form are added to req.Form and req.PostForm like http.Request.ParseMultipartForm.
+func parseMultipartForm(req *http.Request, cfg MultipartConfig) error {
+	if req.MultipartForm != nil {
+		return nil
+	}
+
+	mr, err := multipartReader(req, cfg)
+	if err != nil {
+		return err
+	}
+
+	maxMemory := cfg.MaxMemory
+	if maxMemory <= 0 {
+		maxMemory = DefaultMultipartConfig.MaxMemory
+	}
+
+	form, err := readMultipartForm(mr, cfg, maxMemory)
+	if err != nil {
+		if isBodyTooLarge(req.Body) {
+			return requestTooLargeErr
+		}
+
+		return err
+	}
+
+	if req.Form == nil {
+		req.Form = url.Values{}
+	}
+
+	if req.PostForm == nil {
+		req.PostForm = url.Values{}
+	}
+
+	for k, values := range form.Value {
+		req.Form[k] = append(req.Form[k], values...)
+		req.PostForm[k] = append(req.PostForm[k], values...)
+	}
+
+	req.MultipartForm = form
+	return nil
+}
+
+// readMultipartForm reads a multipart form and checks MaxFileSize and MaxFiles part by part.
+// Parts are streamed to ReadForm through a pipe, so a request is rejected as soon as a file exceeds the limits
+// and the rest of the file is never stored.
+func readMultipartForm(mr *multipart.Reader, cfg MultipartConfig, maxMemory int64) (*multipart.Form, error) {
+	if cfg.MaxFileSize <= 0 && cfg.MaxFiles <= 0 {
+		form, err := mr.ReadForm(maxMemory)
+		if err != nil {
+			return nil, invalidMultipartErr
+		}
+
+		return form, nil
+	}
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	errCh := make(chan error, 1)
+	go func() {
+		err := copyParts(mw, mr, cfg)
+		_ = pw.CloseWithError(err)
+		errCh <- err
+	}()
+
+	form, err := multipart.NewReader(pr, mw.Boundary()).ReadForm(maxMemory)
+	// unblock copyParts if ReadForm stops early
+	_ = pr.Close()
+	copyErr := <-errCh
+	if err == nil {
+		return form, nil
+	}
+
+	var httpErr HTTPError
+	if errors.As(copyErr, &httpErr) {
+		return nil, httpErr
+	}
+
+	return nil, invalidMultipartErr
+}
+
+// copyParts copies parts of a multipart body to a multipart writer while checking limits of files.
+func copyParts(mw *multipart.Writer, mr *multipart.Reader, cfg MultipartConfig) error {
+	files := 0
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			return mw.Close()
+		}
+
+		if err != nil {
+			return err
+		}
+
+		isFile := p.FileName() != ""
+		if isFile {
+			files++
+			if cfg.MaxFiles > 0 && files > cfg.MaxFiles {
+				return tooManyFilesErr
+			}
+		}
+
+		w, err := mw.CreatePart(p.Header)
+		if err != nil {
+			return err
+		}
+
+		if !isFile || cfg.MaxFileSize <= 0 {
+			if _, err := io.Copy(w, p); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := io.Copy(w, io.LimitReader(p, cfg.MaxFileSize)); err != nil {
+			return err
+		}
+
+		// the file exceeds the limit if there is any byte left
+		if _, err := io.ReadFull(p, make([]byte, 1)); err != io.EOF {
+			if err == nil {
+				return fileTooLargeErr
+			}
+			return err
+		}
+	}
+}
+
+func (r *requestImpl) File(name string) (*multipart.FileHeader, error) {
+	files, err := r.Files()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files[name]) == 0 {
+		return nil, &ParamError{Source: sourceFile, Name: name, Err: errMissingParam}
+	}
+
+	return files[name][0], nil
+}
+
+func (r *requestImpl) Files() (map[string][]*multipart.FileHeader, error) {
+	if err := parseMultipartForm(r.httpReq, r.multipartConfig); err != nil {
+		return nil, err
+	}
+
+	return r.httpReq.MultipartForm.File, nil
+}
+
+func (r *requestImpl) MultipartReader() (*multipart.Reader, error) {
+	return multipartReader(r.httpReq, r.multipartConfig)
+}
@@ -0,0 +1,288 @@
+package nanny
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockUploadRequest struct {
+	Title       string                  `form:"title"`
+	Avatar      *multipart.FileHeader   `form:"avatar"`
+	Attachments []*multipart.FileHeader `form:"attachments"`
+}
+
+type mockPart struct {
+	field    string
+	filename string
+	content  string
+}
+
+func newMultipartRequest(t *testing.T, parts ...mockPart) *http.Request {
+	t.Helper()
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	for _, p := range parts {
+		if p.filename == "" {
+			require.NoError(t, mw.WriteField(p.field, p.content))
+			continue
+		}
+
+		w, err := mw.CreateFormFile(p.field, p.filename)
+		require.NoError(t, err)
+		_, err = io.WriteString(w, p.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, mw.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", body)
+	req.Header.Set(HeaderContentType, mw.FormDataContentType())
+	return req
+}
+
+func readFile(t *testing.T, fh *multipart.FileHeader) string {
+	t.Helper()
+	f, err := fh.Open()
+	require.NoError(t, err)
+	defer f.Close()
+	data, err := ioutil.ReadAll(f)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func Test_Request_Multipart(t *testing.T) {
+	parts := []mockPart{
+		{field: "title", content: "mock-title"},
+		{field: "avatar", filename: "avatar.png", content: "mock-avatar"},
+		{field: "attachments", filename: "a.txt", content: "mock-a"},
+		{field: "attachments", filename: "b.txt", content: "mock-b"},
+	}
+
+	t.Run("decode", func(t *testing.T) {
+		reqObj := &mockUploadRequest{}
+		err := NewRequest(newMultipartRequest(t, parts...), nil).Decode(reqObj)
+		require.NoError(t, err)
+		require.Equal(t, "mock-title", reqObj.Title)
+		require.Equal(t, "avatar.png", reqObj.Avatar.Filename)
+		require.Equal(t, "mock-avatar", readFile(t, reqObj.Avatar))
+		require.Len(t, reqObj.Attachments, 2)
+		require.Equal(t, "mock-b", readFile(t, reqObj.Attachments[1]))
+	})
+
+	t.Run("file", func(t *testing.T) {
+		req := NewRequest(newMultipartRequest(t, parts...), nil)
+		fh, err := req.File("avatar")
+		require.NoError(t, err)
+		require.Equal(t, "mock-avatar", readFile(t, fh))
+
+		files, err := req.Files()
+		require.NoError(t, err)
+		require.Len(t, files["attachments"], 2)
+
+		_, err = req.File("missing")
+		require.Equal(t, &ParamError{Source: sourceFile, Name: "missing", Err: errMissingParam}, err)
+	})
+
+	t.Run("not-multipart", func(t *testing.T) {
+		_, err := NewRequest(httptest.NewRequest(http.MethodPost, "/", strings.NewReader("a")), nil).File("avatar")
+		require.Equal(t, invalidMultipartErr, err)
+	})
+
+	t.Run("multipart-reader", func(t *testing.T) {
+		mr, err := NewRequest(newMultipartRequest(t, parts...), nil).MultipartReader()
+		require.NoError(t, err)
+		p, err := mr.NextPart()
+		require.NoError(t, err)
+		require.Equal(t, "title", p.FormName())
+	})
+}
+
+func Test_WithMultipartConfig(t *testing.T) {
+	app := New()
+	handler := func(ctx context.Context, req Request) (interface{}, error) {
+		files, err := req.Files()
+		if err != nil {
+			return nil, err
+		}
+
+		return len(files), nil
+	}
+	app.POST("/size", handler, WithMultipartConfig(MultipartConfig{MaxSize: 100}))
+	app.POST("/file-size", handler, WithMultipartConfig(MultipartConfig{MaxFileSize: 5}))
+	app.POST("/files", handler, WithMultipartConfig(MultipartConfig{MaxFiles: 1}))
+	app.POST("/stream", func(ctx context.Context, req Request) (interface{}, error) {
+		mr, err := req.MultipartReader()
+		if err != nil {
+			return nil, err
+		}
+
+		for {
+			p, err := mr.NextPart()
+			if err == io.EOF {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			if _, err := io.Copy(ioutil.Discard, p); err != nil {
+				return nil, err
+			}
+		}
+	}, WithMultipartConfig(MultipartConfig{MaxSize: 100}))
+
+	parts := []mockPart{
+		{field: "a", filename: "a.txt", content: "mock-a"},
+		{field: "b", filename: "b.txt", content: strings.Repeat("b", 200)},
+	}
+
+	testCases := map[string]struct {
+		path          string
+		chunked       bool
+		expectedError HTTPError
+	}{
+		"size":         {path: "/size", expectedError: requestTooLargeErr},
+		"size-chunked": {path: "/size", chunked: true, expectedError: requestTooLargeErr},
+		"file-size":    {path: "/file-size", expectedError: fileTooLargeErr},
+		"files":        {path: "/files", expectedError: tooManyFilesErr},
+		"stream":       {path: "/stream", chunked: true, expectedError: requestTooLargeErr},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			req := newMultipartRequest(t, parts...)
+			req.URL.Path = tc.path
+			if tc.chunked {
+				req.ContentLength = -1
+			}
+
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, req)
+			require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+			require.Contains(t, w.Body.String(), tc.expectedError.Message)
+		})
+	}
+}
+
+func Test_limitedReader(t *testing.T) {
+	r := newLimitedReader(ioutil.NopCloser(strings.NewReader("12345")), 5)
+	data, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "12345", string(data))
+
+	r = newLimitedReader(ioutil.NopCloser(strings.NewReader("123456")), 5)
+	data, err = ioutil.ReadAll(r)
+	require.Equal(t, requestTooLargeErr, err)
+	require.Equal(t, "12345", string(data))
+	require.True(t, r.exceeded)
+}
+
+// countingReader counts bytes read from a reader.
+type countingReader struct {
+	io.Reader
+	n int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += n
+	return n, err
+}
+
+func Test_WithMultipartConfig_streaming(t *testing.T) {
+	app := New()
+	app.POST("/upload", func(ctx context.Context, req Request) (interface{}, error) {
+		reqObj := &mockUploadRequest{}
+		if err := req.Decode(reqObj); err != nil {
+			return nil, err
+		}
+
+		return readFile(t, reqObj.Avatar), nil
+	}, WithMultipartConfig(MultipartConfig{MaxMemory: 1, MaxFileSize: 20, MaxFiles: 2}))
+
+	t.Run("within-limits", func(t *testing.T) {
+		req := newMultipartRequest(t,
+			mockPart{field: "title", content: "mock-title"},
+			mockPart{field: "avatar", filename: "avatar.png", content: "mock-avatar"},
+		)
+		req.URL.Path = "/upload"
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, `"mock-avatar"`, strings.TrimSpace(w.Body.String()))
+	})
+
+	t.Run("file-too-large", func(t *testing.T) {
+		req := newMultipartRequest(t, mockPart{field: "avatar", filename: "avatar.png", content: strings.Repeat("a", 1<<20)})
+		req.URL.Path = "/upload"
+		body := &countingReader{Reader: req.Body}
+		req.Body = ioutil.NopCloser(body)
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		require.Contains(t, w.Body.String(), fileTooLargeErr.Message)
+		require.Less(t, body.n, 1<<20)
+	})
+
+	t.Run("too-many-files", func(t *testing.T) {
+		req := newMultipartRequest(t,
+			mockPart{field: "attachments", filename: "a.txt", content: "mock-a"},
+			mockPart{field: "attachments", filename: "b.txt", content: "mock-b"},
+			mockPart{field: "attachments", filename: "c.txt", content: "mock-c"},
+		)
+		req.URL.Path = "/upload"
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		require.Contains(t, w.Body.String(), tooManyFilesErr.Message)
+	})
+}
+
+func Test_Multipart_removeTempFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nanny-multipart")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	tmpDir, hasTmpDir := os.LookupEnv("TMPDIR")
+	require.NoError(t, os.Setenv("TMPDIR", dir))
+	defer func() {
+		if hasTmpDir {
+			_ = os.Setenv("TMPDIR", tmpDir)
+			return
+		}
+		_ = os.Unsetenv("TMPDIR")
+	}()
+
+	app := New()
+	app.POST("/upload", func(ctx context.Context, req Request) (interface{}, error) {
+		if _, err := req.File("avatar"); err != nil {
+			return nil, err
+		}
+
+		entries, err := ioutil.ReadDir(dir)
+		return len(entries), err
+	}, WithMultipartConfig(MultipartConfig{MaxMemory: 1}))
+
+	req := newMultipartRequest(t, mockPart{field: "avatar", filename: "avatar.png", content: "mock-avatar"})
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "1", strings.TrimSpace(w.Body.String()))
+
+	entries, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
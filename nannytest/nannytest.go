@@ -0,0 +1,40 @@
+// Package nannytest provides utilities for testing nanny applications without a network.
+package nannytest
+
+import (
+	"net/http"
+
+	"github.com/bongnv/nanny"
+	"github.com/julienschmidt/httprouter"
+)
+
+// New creates an Application for testing. Components specified via Override
+// are registered before other options, so they replace components with the same name.
+func New(opts ...nanny.Option) *nanny.Application {
+	var overrides, others []nanny.Option
+	for _, o := range opts {
+		if _, ok := o.(overrideOption); ok {
+			overrides = append(overrides, o)
+		} else {
+			others = append(others, o)
+		}
+	}
+
+	return nanny.New(append(overrides, others...)...)
+}
+
+// Override replaces a component registered in the Application with a fake one.
+func Override(name string, component interface{}) nanny.Option {
+	return overrideOption{
+		OptionFn: nanny.WithOverride(name, component),
+	}
+}
+
+type overrideOption struct {
+	nanny.OptionFn
+}
+
+// Call calls a Handler directly with a Request built from httpReq and path params.
+func Call(h nanny.Handler, httpReq *http.Request, params ...httprouter.Param) (interface{}, error) {
+	return h(httpReq.Context(), nanny.NewRequest(httpReq, params))
+}
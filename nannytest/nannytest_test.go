@@ -0,0 +1,94 @@
+package nannytest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bongnv/nanny"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/require"
+)
+
+type greeter interface {
+	Greet(name string) string
+}
+
+type realGreeter struct{}
+
+func (realGreeter) Greet(name string) string {
+	return "Hello " + name
+}
+
+type fakeGreeter struct{}
+
+func (fakeGreeter) Greet(name string) string {
+	return "Fake " + name
+}
+
+type greetRequest struct {
+	Name string `json:"name"`
+}
+
+type greetResponse struct {
+	Message string `json:"message"`
+}
+
+type greetService struct {
+	Greeter greeter `inject:"greeter"`
+}
+
+func (s *greetService) greet(ctx context.Context, req nanny.Request) (interface{}, error) {
+	reqDto := &greetRequest{}
+	if err := req.Decode(reqDto); err != nil {
+		return nil, err
+	}
+
+	return &greetResponse{Message: s.Greeter.Greet(reqDto.Name)}, nil
+}
+
+func withGreeter() nanny.OptionFn {
+	return func(app *nanny.Application) {
+		app.MustRegister("greeter", realGreeter{})
+		app.MustRegister("service", &greetService{})
+	}
+}
+
+func Test_New_Override(t *testing.T) {
+	app := New(withGreeter(), Override("greeter", fakeGreeter{}))
+	require.IsType(t, fakeGreeter{}, app.MustComponent("greeter"))
+
+	s := app.MustComponent("service").(*greetService)
+	app.POST("/greet/:name", s.greet)
+	out := &greetResponse{}
+	POST(app, "/greet/nanny").Expect(t).Status(http.StatusOK).JSON(out)
+	require.Equal(t, "Fake nanny", out.Message)
+}
+
+func Test_RequestBuilder(t *testing.T) {
+	app := nanny.New()
+	s := &greetService{Greeter: realGreeter{}}
+	app.POST("/greet", s.greet)
+
+	out := &greetResponse{}
+	POST(app, "/greet").
+		WithJSON(&greetRequest{Name: "nanny"}).
+		WithHeader("X-Request-ID", "mock-id").
+		Expect(t).
+		Status(http.StatusOK).
+		Header(nanny.HeaderContentType, "application/json").
+		JSON(out)
+	require.Equal(t, "Hello nanny", out.Message)
+
+	resp := GET(app, "/greet").Expect(t).Status(http.StatusMethodNotAllowed)
+	require.NotEmpty(t, resp.Body())
+}
+
+func Test_Call(t *testing.T) {
+	s := &greetService{Greeter: fakeGreeter{}}
+	httpReq := httptest.NewRequest(http.MethodPost, "/greet/nanny", nil)
+	resp, err := Call(s.greet, httpReq, httprouter.Param{Key: "name", Value: "nanny"})
+	require.NoError(t, err)
+	require.Equal(t, &greetResponse{Message: "Fake nanny"}, resp)
+}
@@ -0,0 +1,149 @@
+package nannytest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bongnv/nanny"
+)
+
+// RequestBuilder builds an HTTP request to send to an http.Handler.
+type RequestBuilder struct {
+	handler http.Handler
+	method  string
+	target  string
+	header  http.Header
+	body    io.Reader
+	err     error
+}
+
+// NewRequest creates a RequestBuilder to send a request to h. h is usually a nanny.Application.
+func NewRequest(h http.Handler, method, target string) *RequestBuilder {
+	return &RequestBuilder{
+		handler: h,
+		method:  method,
+		target:  target,
+		header:  http.Header{},
+	}
+}
+
+// GET creates a RequestBuilder for a GET request.
+func GET(h http.Handler, target string) *RequestBuilder {
+	return NewRequest(h, http.MethodGet, target)
+}
+
+// POST creates a RequestBuilder for a POST request.
+func POST(h http.Handler, target string) *RequestBuilder {
+	return NewRequest(h, http.MethodPost, target)
+}
+
+// PUT creates a RequestBuilder for a PUT request.
+func PUT(h http.Handler, target string) *RequestBuilder {
+	return NewRequest(h, http.MethodPut, target)
+}
+
+// PATCH creates a RequestBuilder for a PATCH request.
+func PATCH(h http.Handler, target string) *RequestBuilder {
+	return NewRequest(h, http.MethodPatch, target)
+}
+
+// DELETE creates a RequestBuilder for a DELETE request.
+func DELETE(h http.Handler, target string) *RequestBuilder {
+	return NewRequest(h, http.MethodDelete, target)
+}
+
+// WithHeader adds a header to the request.
+func (b *RequestBuilder) WithHeader(key, value string) *RequestBuilder {
+	b.header.Add(key, value)
+	return b
+}
+
+// WithBody specifies the body of the request and its content type.
+func (b *RequestBuilder) WithBody(contentType string, body io.Reader) *RequestBuilder {
+	b.header.Set(nanny.HeaderContentType, contentType)
+	b.body = body
+	return b
+}
+
+// WithJSON encodes obj as JSON and uses it as the body of the request.
+func (b *RequestBuilder) WithJSON(obj interface{}) *RequestBuilder {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	return b.WithBody("application/json", bytes.NewReader(data))
+}
+
+// Expect sends the request and returns the Response for assertions.
+func (b *RequestBuilder) Expect(t testing.TB) *Response {
+	t.Helper()
+
+	if b.err != nil {
+		t.Fatalf("nannytest: error when building request: %v", b.err)
+	}
+
+	req := httptest.NewRequest(b.method, b.target, b.body)
+	for key, values := range b.header {
+		req.Header[key] = values
+	}
+
+	rr := httptest.NewRecorder()
+	b.handler.ServeHTTP(rr, req)
+
+	return &Response{
+		Recorder: rr,
+		t:        t,
+	}
+}
+
+// Response is the response of a request to make assertions.
+type Response struct {
+	// Recorder records the response.
+	Recorder *httptest.ResponseRecorder
+
+	t testing.TB
+}
+
+// Status asserts the status code of the response.
+func (r *Response) Status(code int) *Response {
+	r.t.Helper()
+
+	if r.Recorder.Code != code {
+		r.t.Fatalf("nannytest: expected status %d but got %d with body %q", code, r.Recorder.Code, r.Recorder.Body.String())
+	}
+
+	return r
+}
+
+// Header asserts the value of a header in the response.
+func (r *Response) Header(key, value string) *Response {
+	r.t.Helper()
+
+	if got := r.Recorder.Header().Get(key); got != value {
+		r.t.Fatalf("nannytest: expected header %s to be %q but got %q", key, value, got)
+	}
+
+	return r
+}
+
+// JSON decodes the body of the response as JSON into out.
+func (r *Response) JSON(out interface{}) *Response {
+	r.t.Helper()
+
+	if err := json.Unmarshal(r.Recorder.Body.Bytes(), out); err != nil {
+		r.t.Fatalf("nannytest: error when decoding %q as JSON: %v", r.Recorder.Body.String(), err)
+	}
+
+	return r
+}
+
+// Body returns the body of the response.
+func (r *Response) Body() string {
+	return r.Recorder.Body.String()
+}
@@ -0,0 +1,262 @@
+package nanny
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrUnsupportedResponse is returned by a ResponseEncoderFunc if it can't encode a response,
+// so the next acceptable media type is tried.
+var ErrUnsupportedResponse = errors.New("nanny: unsupported response")
+
+// errors of content negotiation.
+var (
+	notAcceptableErr = HTTPError{Code: http.StatusNotAcceptable, Message: "Not Acceptable"}
+	encodingErr      = HTTPError{Code: http.StatusInternalServerError, Message: "Internal Server Error"}
+)
+
+// ResponseEncoderFunc encodes a response and writes to w.
+type ResponseEncoderFunc func(w io.Writer, resp interface{}) error
+
+type responseEncoder struct {
+	mediaType string
+	encode    ResponseEncoderFunc
+}
+
+// WithResponseEncoder registers a ResponseEncoderFunc for a media type which can be negotiated via the Accept header.
+// It replaces the existing one if the media type is already registered. It only applies to the default Encoder.
+func WithResponseEncoder(mediaType string, fn ResponseEncoderFunc) RouteOptionFn {
+	return func(r *route) {
+		e, ok := r.encoder.(defaultEncoder)
+		if !ok {
+			return
+		}
+
+		r.encoder = e.withResponseEncoder(mediaType, fn)
+	}
+}
+
+func defaultResponseEncoders() []responseEncoder {
+	return []responseEncoder{
+		{mediaType: jsonScheme, encode: encodeJSON},
+		{mediaType: "application/xml", encode: encodeXML},
+		{mediaType: "application/msgpack", encode: encodeMsgpack},
+		{mediaType: "application/x-protobuf", encode: encodeProtobuf},
+		{mediaType: "text/csv", encode: encodeCSV},
+	}
+}
+
+func (e defaultEncoder) withResponseEncoder(mediaType string, fn ResponseEncoderFunc) defaultEncoder {
+	encoders := make([]responseEncoder, 0, len(e.encoders)+1)
+	replaced := false
+	for _, enc := range e.encoders {
+		if strings.EqualFold(enc.mediaType, mediaType) {
+			enc.encode = fn
+			replaced = true
+		}
+		encoders = append(encoders, enc)
+	}
+
+	if !replaced {
+		encoders = append(encoders, responseEncoder{mediaType: mediaType, encode: fn})
+	}
+
+	return defaultEncoder{encoders: encoders}
+}
+
+type acceptRange struct {
+	mediaType string
+	q         float64
+}
+
+// parseAccept parses an Accept header into media ranges. Invalid media ranges are ignored.
+func parseAccept(accept string) []acceptRange {
+	var ranges []acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+
+		ranges = append(ranges, acceptRange{mediaType: mediaType, q: q})
+	}
+
+	return ranges
+}
+
+// negotiate returns acceptable encoders in the order of preference.
+// The quality of an encoder is from the most specific media range which matches it.
+// Encoders with the same quality keep their registration order.
+// All encoders are acceptable if there is no Accept header.
+// An Accept header listing text/html is sent by browsers navigating to a page, its q-values of other media types
+// like application/xml;q=0.9 aren't meaningful for APIs, so acceptable encoders keep their registration order and JSON is preferred.
+func negotiate(accept string, encoders []responseEncoder) []responseEncoder {
+	if strings.TrimSpace(accept) == "" {
+		return encoders
+	}
+
+	ranges := parseAccept(accept)
+	browser := false
+	for _, r := range ranges {
+		if r.mediaType == "text/html" && r.q > 0 {
+			browser = true
+		}
+	}
+	type candidate struct {
+		encoder responseEncoder
+		q       float64
+	}
+
+	var candidates []candidate
+	for _, enc := range encoders {
+		q, specificity := 0.0, -1
+		for _, r := range ranges {
+			if s := matchMediaRange(r.mediaType, enc.mediaType); s > specificity {
+				q, specificity = r.q, s
+			}
+		}
+
+		if q > 0 {
+			candidates = append(candidates, candidate{encoder: enc, q: q})
+		}
+	}
+
+	if !browser {
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].q > candidates[j].q
+		})
+	}
+
+	result := make([]responseEncoder, 0, len(candidates))
+	for _, c := range candidates {
+		result = append(result, c.encoder)
+	}
+
+	return result
+}
+
+// matchMediaRange returns the specificity of a media range matching a media type, -1 if it doesn't match.
+func matchMediaRange(mediaRange, mediaType string) int {
+	mediaType = strings.ToLower(mediaType)
+	switch {
+	case mediaRange == "*/*":
+		return 0
+	case strings.HasSuffix(mediaRange, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(mediaRange, "*")):
+		return 1
+	case mediaRange == mediaType:
+		return 2
+	}
+
+	return -1
+}
+
+func encodeJSON(w io.Writer, resp interface{}) error {
+	return json.NewEncoder(w).Encode(resp)
+}
+
+func encodeXML(w io.Writer, resp interface{}) error {
+	return xml.NewEncoder(w).Encode(resp)
+}
+
+func encodeMsgpack(w io.Writer, resp interface{}) error {
+	return msgpack.NewEncoder(w).Encode(resp)
+}
+
+func encodeProtobuf(w io.Writer, resp interface{}) error {
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return ErrUnsupportedResponse
+	}
+
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// encodeCSV encodes a slice of structs as CSV with a header row.
+// Column names are from csv tags, json tags or field names. Fields tagged with "-" are skipped.
+func encodeCSV(w io.Writer, resp interface{}) error {
+	v := reflect.Indirect(reflect.ValueOf(resp))
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return ErrUnsupportedResponse
+	}
+
+	elemType := v.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+
+	if elemType.Kind() != reflect.Struct {
+		return ErrUnsupportedResponse
+	}
+
+	var header []string
+	var indexes []int
+	for i := 0; i < elemType.NumField(); i++ {
+		sf := elemType.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		name := tagName(sf, "csv")
+		if name == "" {
+			name = tagName(sf, "json")
+		}
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+
+		header = append(header, name)
+		indexes = append(indexes, i)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(indexes))
+	for i := 0; i < v.Len(); i++ {
+		elem := reflect.Indirect(v.Index(i))
+		for j, idx := range indexes {
+			record[j] = ""
+			if elem.IsValid() {
+				record[j] = fmt.Sprint(elem.Field(idx).Interface())
+			}
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
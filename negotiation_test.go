@@ -0,0 +1,264 @@
+package nanny
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type mockUser struct {
+	ID       int    `json:"id" xml:"id" msgpack:"id"`
+	Name     string `json:"name" xml:"name" msgpack:"name" csv:"full_name"`
+	Password string `json:"-" xml:"-" msgpack:"-"`
+}
+
+// browserAccept is the Accept header sent by browsers when navigating to a page.
+const browserAccept = "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"
+
+func Test_negotiate(t *testing.T) {
+	encoders := defaultResponseEncoders()
+	mediaTypes := func(encoders []responseEncoder) []string {
+		var result []string
+		for _, enc := range encoders {
+			result = append(result, enc.mediaType)
+		}
+		return result
+	}
+
+	testCases := map[string]struct {
+		accept   string
+		expected []string
+	}{
+		"empty":    {accept: "", expected: []string{"application/json", "application/xml", "application/msgpack", "application/x-protobuf", "text/csv"}},
+		"q-values": {accept: "application/json;q=0.5, application/xml", expected: []string{"application/xml", "application/json"}},
+		"browser":  {accept: browserAccept, expected: []string{"application/json", "application/xml", "application/msgpack", "application/x-protobuf", "text/csv"}},
+		"wildcard": {accept: "text/*, application/json;q=0.1", expected: []string{"text/csv", "application/json"}},
+		"excluded": {accept: "*/*;q=0.5, application/json;q=0", expected: []string{"application/xml", "application/msgpack", "application/x-protobuf", "text/csv"}},
+		"none":     {accept: "image/png", expected: nil},
+		"invalid":  {accept: "application/json;q=abc, text/csv", expected: []string{"text/csv"}},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, mediaTypes(negotiate(tc.accept, encoders)))
+		})
+	}
+}
+
+func Test_defaultEncoder_Negotiation(t *testing.T) {
+	users := []*mockUser{{ID: 1, Name: "John", Password: "secret"}, {ID: 2, Name: "Jane, Doe"}}
+	msgpackBody, err := msgpack.Marshal(users)
+	require.NoError(t, err)
+	protobufBody, err := proto.Marshal(wrapperspb.String("mock-value"))
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		accept              string
+		resp                interface{}
+		expectedCode        int
+		expectedContentType string
+		expectedBody        string
+	}{
+		"default": {
+			resp:                users,
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody:        "[{\"id\":1,\"name\":\"John\"},{\"id\":2,\"name\":\"Jane, Doe\"}]\n",
+		},
+		"xml": {
+			accept:              "application/xml",
+			resp:                users[0],
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/xml",
+			expectedBody:        "<mockUser><id>1</id><name>John</name></mockUser>",
+		},
+		"browser-struct": {
+			accept:              browserAccept,
+			resp:                users[0],
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody:        "{\"id\":1,\"name\":\"John\"}\n",
+		},
+		"browser-map": {
+			accept:              browserAccept,
+			resp:                map[string]int{"count": 1},
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody:        "{\"count\":1}\n",
+		},
+		"msgpack": {
+			accept:              "application/msgpack",
+			resp:                users,
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/msgpack",
+			expectedBody:        string(msgpackBody),
+		},
+		"csv": {
+			accept:              "text/csv",
+			resp:                users,
+			expectedCode:        http.StatusOK,
+			expectedContentType: "text/csv",
+			expectedBody:        "id,full_name\n1,John\n2,\"Jane, Doe\"\n",
+		},
+		"protobuf": {
+			accept:              "application/x-protobuf",
+			resp:                wrapperspb.String("mock-value"),
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/x-protobuf",
+			expectedBody:        string(protobufBody),
+		},
+		"protobuf-fallback": {
+			accept:              "application/x-protobuf, application/json;q=0.5",
+			resp:                users[0],
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody:        "{\"id\":1,\"name\":\"John\"}\n",
+		},
+		"not-acceptable": {
+			accept:              "text/csv",
+			resp:                users[0],
+			expectedCode:        http.StatusNotAcceptable,
+			expectedContentType: "application/json",
+			expectedBody:        "{\"message\":\"Not Acceptable\"}\n",
+		},
+		"response": {
+			resp:                Created(users[0], "/users/1"),
+			expectedCode:        http.StatusCreated,
+			expectedContentType: "application/json",
+			expectedBody:        "{\"id\":1,\"name\":\"John\"}\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.accept != "" {
+				req.Header.Set(HeaderAccept, tc.accept)
+			}
+
+			w := httptest.NewRecorder()
+			err := newEncoder().(RequestEncoder).EncodeRequest(w, req, tc.resp)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, w.Code)
+			require.Equal(t, tc.expectedContentType, w.Header().Get(HeaderContentType))
+			require.Equal(t, tc.expectedBody, w.Body.String())
+			require.Equal(t, HeaderAccept, w.Header().Get(HeaderVary))
+		})
+	}
+}
+
+func Test_WithResponseEncoder(t *testing.T) {
+	app := New(WithResponseEncoder("text/plain", func(w io.Writer, resp interface{}) error {
+		s, ok := resp.(string)
+		if !ok {
+			return ErrUnsupportedResponse
+		}
+
+		_, err := io.WriteString(w, s)
+		return err
+	}))
+	app.GET("/hello", func(ctx context.Context, req Request) (interface{}, error) {
+		return "hello", nil
+	})
+	app.GET("/json", func(ctx context.Context, req Request) (interface{}, error) {
+		return "hello", nil
+	}, WithResponseEncoder("application/json", func(w io.Writer, resp interface{}) error {
+		_, err := io.WriteString(w, `{"custom":true}`)
+		return err
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/hello", nil)
+	req.Header.Set(HeaderAccept, "text/plain")
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/plain", w.Header().Get(HeaderContentType))
+	require.Equal(t, "hello", w.Body.String())
+
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/json", nil))
+	require.Equal(t, `{"custom":true}`, w.Body.String())
+
+	t.Run("custom-encoder", func(t *testing.T) {
+		e := &mockEncoder{}
+		r := &route{encoder: e}
+		WithResponseEncoder("text/plain", nil).ApplyRoute(r)
+		require.Equal(t, e, r.encoder)
+	})
+}
+
+type mockEncoder struct{}
+
+func (e *mockEncoder) Encode(w http.ResponseWriter, resp interface{}) error {
+	return nil
+}
+
+func Test_defaultEncoder_EncodeError(t *testing.T) {
+	e := newEncoder().(defaultEncoder)
+	encode := func(e defaultEncoder, accept string, resp interface{}) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(HeaderAccept, accept)
+		w := httptest.NewRecorder()
+		err := e.EncodeRequest(w, req, resp)
+		return w, err
+	}
+
+	t.Run("xml", func(t *testing.T) {
+		w, err := encode(e, "application/xml, application/json;q=0.5", &mockUser{ID: 1, Name: "John"})
+		require.NoError(t, err)
+		require.Equal(t, "application/xml", w.Header().Get(HeaderContentType))
+		require.Equal(t, "<mockUser><id>1</id><name>John</name></mockUser>", w.Body.String())
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		w, err := encode(e, "application/xml, application/json;q=0.5", map[string]int{"count": 1})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/json", w.Header().Get(HeaderContentType))
+		require.Equal(t, "{\"count\":1}\n", w.Body.String())
+	})
+
+	t.Run("all-failed", func(t *testing.T) {
+		failing := defaultEncoder{}.withResponseEncoder("application/xml", encodeXML)
+		w, err := encode(failing, "application/xml", map[string]int{"count": 1})
+		require.Error(t, err)
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Equal(t, "{\"message\":\"Internal Server Error\"}\n", w.Body.String())
+	})
+}
+
+func Test_Negotiation_Application(t *testing.T) {
+	app := New()
+	app.GET("/users/1", func(ctx context.Context, req Request) (interface{}, error) {
+		return &mockUser{ID: 1, Name: "John"}, nil
+	})
+
+	testCases := map[string]struct {
+		accept              string
+		expectedContentType string
+	}{
+		"xml":    {accept: "application/xml", expectedContentType: "application/xml"},
+		"chrome": {accept: "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8,application/signed-exchange;v=b3;q=0.7", expectedContentType: "application/json"},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+			req.Header.Set(HeaderAccept, tc.accept)
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code)
+			require.Equal(t, tc.expectedContentType, w.Header().Get(HeaderContentType))
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package nanny
+
+import (
+	"context"
+	"net/http"
+)
+
+// WithNotFoundHandler specifies the Handler for requests which don't match any route.
+// Its result is handled with application-level route options like other routes.
+func WithNotFoundHandler(h Handler) OptionFn {
+	return func(app *Application) {
+		if h != nil {
+			app.notFoundHandler = h
+		}
+	}
+}
+
+// WithMethodNotAllowedHandler specifies the Handler for requests which match a path but not its methods.
+// Its result is handled with application-level route options like other routes.
+// The Allow header is set with allowed methods before the Handler is called.
+func WithMethodNotAllowedHandler(h Handler) OptionFn {
+	return func(app *Application) {
+		if h != nil {
+			app.methodNotAllowedHandler = h
+		}
+	}
+}
+
+func defaultNotFoundHandler(_ context.Context, _ Request) (interface{}, error) {
+	return nil, notFoundErr
+}
+
+func defaultMethodNotAllowedHandler(_ context.Context, _ Request) (interface{}, error) {
+	return nil, methodNotAllowedErr
+}
+
+// buildAppLevelHandler builds an http.Handler for a Handler which isn't registered with any path.
+// Application-level route options are applied to it.
+func (app *Application) buildAppLevelHandler(h Handler) http.Handler {
+	r := app.newRoute("", "", h)
+	r.applyOpts(app.routeOptions)
+	return r.buildHTTPHandler()
+}
@@ -0,0 +1,57 @@
+package nanny
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_defaultNotFoundHandler(t *testing.T) {
+	app := New(WithCORS(DefaultCORSConfig))
+	app.GET("/users/:id", mockHandler)
+
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/not-found", nil))
+	require.Equal(t, http.StatusNotFound, rr.Code)
+	require.Equal(t, "application/json", rr.Header().Get(HeaderContentType))
+	require.Equal(t, "*", rr.Header().Get(HeaderAccessControlAllowOrigin), "app-level options must be applied")
+	require.JSONEq(t, `{"message":"Not Found"}`, rr.Body.String())
+}
+
+func Test_defaultMethodNotAllowedHandler(t *testing.T) {
+	app := New()
+	app.GET("/users/:id", mockHandler)
+	app.PUT("/users/:id", mockHandler)
+
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/users/1", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	require.Equal(t, "GET, OPTIONS, PUT", rr.Header().Get("Allow"))
+	require.JSONEq(t, `{"message":"Method Not Allowed"}`, rr.Body.String())
+}
+
+func Test_WithNotFoundHandler(t *testing.T) {
+	app := New(WithNotFoundHandler(func(ctx context.Context, req Request) (interface{}, error) {
+		return nil, HTTPError{Code: http.StatusNotFound, Message: "No route for " + req.HTTPRequest().URL.Path}
+	}))
+
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/not-found", nil))
+	require.Equal(t, http.StatusNotFound, rr.Code)
+	require.JSONEq(t, `{"message":"No route for /not-found"}`, rr.Body.String())
+}
+
+func Test_WithMethodNotAllowedHandler(t *testing.T) {
+	app := New(WithMethodNotAllowedHandler(func(ctx context.Context, req Request) (interface{}, error) {
+		return nil, HTTPError{Code: http.StatusMethodNotAllowed, Message: "Allowed methods: " + ResponseHeaderFromCtx(ctx).Get("Allow")}
+	}))
+	app.GET("/users/:id", mockHandler)
+
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/users/1", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	require.JSONEq(t, `{"message":"Allowed methods: GET, OPTIONS"}`, rr.Body.String())
+}
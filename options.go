@@ -13,9 +13,19 @@ func (opt OptionFn) Apply(app *Application) {
 	opt(app)
 }
 
-// WithAddress specifies the TCP address for the server to listen on,
-func WithAddress(addr string) OptionFn {
+// WithAddress specifies the addresses for the server to listen on.
+// An address is either a TCP address like ":8080" or a Unix socket like "unix:///path/to/app.sock".
+func WithAddress(addrs ...string) OptionFn {
 	return func(app *Application) {
-		app.addr = addr
+		app.addrs = addrs
+	}
+}
+
+// WithOverride registers a component which replaces any component registered later with the same name.
+// It's useful to replace components with fakes in tests, so it should be the first Option.
+func WithOverride(name string, component interface{}) OptionFn {
+	return func(app *Application) {
+		app.MustRegister(name, component)
+		app.overrides[name] = true
 	}
 }
@@ -20,5 +20,11 @@ func Test_WithAddress(t *testing.T) {
 	opt := WithAddress(":http")
 	app := New()
 	opt.Apply(app)
-	require.Equal(t, ":http", app.addr)
+	require.Equal(t, []string{":http"}, app.addrs)
+}
+
+func Test_WithOverride(t *testing.T) {
+	fakeLogger := log.New(os.Stdout, "fake", log.LstdFlags)
+	app := New(WithOverride("logger", fakeLogger), WithLogger(defaultLogger()))
+	require.Equal(t, fakeLogger, app.MustComponent("logger"))
 }
@@ -0,0 +1,91 @@
+package nanny
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// sources of request params.
+const (
+	sourcePath   = "path"
+	sourceQuery  = "query"
+	sourceHeader = "header"
+	sourceCookie = "cookie"
+	sourceFile   = "file"
+)
+
+var (
+	errMissingParam = errors.New("missing value")
+	errInvalidInt   = errors.New("not an integer")
+)
+
+// ParamError is an error of a missing or invalid param of a request.
+// It's responded with 400 status code by the default ErrorHandler.
+type ParamError struct {
+	// Source is where the param is from, i.e. path, query, header or cookie.
+	Source string
+	// Name is the name of the param.
+	Name string
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements error interface.
+func (err *ParamError) Error() string {
+	return fmt.Sprintf("invalid %s param %q: %v", err.Source, err.Name, err.Err)
+}
+
+// Unwrap returns the underlying error.
+func (err *ParamError) Unwrap() error {
+	return err.Err
+}
+
+// WriteTo implements CustomHTTPResponse. It encodes the error as HTTPError with 400 status code.
+func (err *ParamError) WriteTo(w http.ResponseWriter) {
+	HTTPError{Code: http.StatusBadRequest, Message: err.Error()}.WriteTo(w)
+}
+
+// ParamErrors contains errors of params of a request.
+// It's responded with 400 status code and a list of invalid params by the default ErrorHandler.
+type ParamErrors []*ParamError
+
+// Error implements error interface.
+func (errs ParamErrors) Error() string {
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// WriteTo implements CustomHTTPResponse. It encodes the errors as JSON format.
+func (errs ParamErrors) WriteTo(w http.ResponseWriter) {
+	type paramErrorResp struct {
+		Source  string `json:"source"`
+		Name    string `json:"name"`
+		Message string `json:"message"`
+	}
+
+	resp := struct {
+		Message string           `json:"message"`
+		Errors  []paramErrorResp `json:"errors"`
+	}{
+		Message: "Invalid Params",
+	}
+
+	for _, err := range errs {
+		resp.Errors = append(resp.Errors, paramErrorResp{
+			Source:  err.Source,
+			Name:    err.Name,
+			Message: err.Err.Error(),
+		})
+	}
+
+	w.Header().Add(HeaderContentType, jsonScheme)
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(resp)
+}
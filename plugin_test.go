@@ -9,6 +9,6 @@ import (
 func Test_Plugin_Apply(t *testing.T) {
 	app := New()
 	DefaultApp.Apply(app)
-	require.Len(t, app.routeOptions, 8)
+	require.Len(t, app.routeOptions, 9)
 	require.NotNil(t, app.logger)
 }
@@ -35,6 +35,6 @@ func WithRecovery() RouteOptionFn {
 			}
 		}
 
-		r.middlewares = append(r.middlewares, m)
+		r.addMiddleware("nanny.WithRecovery", m)
 	}
 }
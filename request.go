@@ -1,7 +1,10 @@
 package nanny
 
 import (
+	"context"
+	"mime/multipart"
 	"net/http"
+	"strconv"
 
 	"github.com/julienschmidt/httprouter"
 )
@@ -10,14 +13,46 @@ import (
 type Request interface {
 	// HTTPRequest returns the http.Request.
 	HTTPRequest() *http.Request
-	// Decode decodes the request to an object.
+	// Decode decodes the request to an object and validates it with the Validator of the route.
 	Decode(obj interface{}) error
+	// Param returns the value of a path param. It returns an empty string if the param doesn't exist.
+	Param(name string) string
+	// ParamInt returns the value of a path param as an int.
+	// It returns a ParamError if the param doesn't exist or isn't an int.
+	ParamInt(name string) (int, error)
+	// Query returns the first value of a query param. It returns an empty string if the param doesn't exist.
+	Query(name string) string
+	// Header returns the first value of a request header. It returns an empty string if the header doesn't exist.
+	Header(name string) string
+	// Cookie returns a cookie of the request. It returns a ParamError if the cookie doesn't exist.
+	Cookie(name string) (*http.Cookie, error)
+	// File returns the first file of a multipart form field. It returns a ParamError if the file doesn't exist.
+	File(name string) (*multipart.FileHeader, error)
+	// Files returns files of a multipart form by field names.
+	Files() (map[string][]*multipart.FileHeader, error)
+	// MultipartReader returns a reader to stream parts of a multipart body without parsing the whole body.
+	// Only MaxSize of the MultipartConfig is applied as parts are read by the handler.
+	MultipartReader() (*multipart.Reader, error)
+}
+
+// NewRequest creates a Request from an http.Request and path params using the default Decoder.
+// It's useful to call a Handler directly, e.g. in tests.
+func NewRequest(httpReq *http.Request, params httprouter.Params) Request {
+	return &requestImpl{
+		decoder:         newDecoder(),
+		httpReq:         httpReq,
+		multipartConfig: DefaultMultipartConfig,
+		params:          params,
+		validator:       newValidator(),
+	}
 }
 
 type requestImpl struct {
-	decoder Decoder
-	httpReq *http.Request
-	params  httprouter.Params
+	decoder         Decoder
+	httpReq         *http.Request
+	multipartConfig MultipartConfig
+	params          httprouter.Params
+	validator       Validator
 }
 
 func (r *requestImpl) HTTPRequest() *http.Request {
@@ -26,12 +61,66 @@ func (r *requestImpl) HTTPRequest() *http.Request {
 
 func (r *requestImpl) Decode(obj interface{}) error {
 	if err := r.httpReq.ParseForm(); err != nil {
+		if isBodyTooLarge(r.httpReq.Body) {
+			return requestTooLargeErr
+		}
+
+		return invalidBodyErr
+	}
+
+	if mediaType, _ := parseMediaType(r.httpReq); mediaType == mediaTypeMultipart {
+		if err := parseMultipartForm(r.httpReq, r.multipartConfig); err != nil {
+			return err
+		}
+	}
+
+	httpReq := r.httpReq
+	if len(r.params) > 0 && len(ParamsFromCtx(httpReq.Context())) == 0 {
+		httpReq = httpReq.WithContext(context.WithValue(httpReq.Context(), ctxKeyParams, r.params))
+	}
+
+	if err := r.decoder.Decode(obj, httpReq); err != nil {
 		return err
 	}
 
-	for _, p := range r.params {
-		r.httpReq.Form.Set(p.Key, p.Value)
+	if r.validator == nil {
+		return nil
+	}
+
+	return r.validator.Validate(obj)
+}
+
+func (r *requestImpl) Param(name string) string {
+	return r.params.ByName(name)
+}
+
+func (r *requestImpl) ParamInt(name string) (int, error) {
+	value := r.Param(name)
+	if value == "" {
+		return 0, &ParamError{Source: sourcePath, Name: name, Err: errMissingParam}
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &ParamError{Source: sourcePath, Name: name, Err: errInvalidInt}
+	}
+
+	return i, nil
+}
+
+func (r *requestImpl) Query(name string) string {
+	return r.httpReq.URL.Query().Get(name)
+}
+
+func (r *requestImpl) Header(name string) string {
+	return r.httpReq.Header.Get(name)
+}
+
+func (r *requestImpl) Cookie(name string) (*http.Cookie, error) {
+	c, err := r.httpReq.Cookie(name)
+	if err != nil {
+		return nil, &ParamError{Source: sourceCookie, Name: name, Err: errMissingParam}
 	}
 
-	return r.decoder.Decode(obj, r.httpReq)
+	return c, nil
 }
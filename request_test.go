@@ -1,6 +1,7 @@
 package nanny
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -30,3 +31,50 @@ func Test_Decode(t *testing.T) {
 	require.Equal(t, "mock-name", reqObj.Name)
 	require.Equal(t, 10, reqObj.Age)
 }
+
+func Test_requestImpl_accessors(t *testing.T) {
+	httpReq := httptest.NewRequest(http.MethodGet, "/users/10?page=2", nil)
+	httpReq.Header.Set("X-Tenant", "mock-tenant")
+	httpReq.AddCookie(&http.Cookie{Name: "session", Value: "mock-session"})
+	r := NewRequest(httpReq, httprouter.Params{
+		{Key: "id", Value: "10"},
+		{Key: "name", Value: "nanny"},
+	})
+
+	require.Equal(t, "10", r.Param("id"))
+	require.Equal(t, "", r.Param("not-found"))
+	require.Equal(t, "2", r.Query("page"))
+	require.Equal(t, "mock-tenant", r.Header("X-Tenant"))
+
+	id, err := r.ParamInt("id")
+	require.NoError(t, err)
+	require.Equal(t, 10, id)
+
+	_, err = r.ParamInt("name")
+	require.EqualError(t, err, `invalid path param "name": not an integer`)
+	require.IsType(t, &ParamError{}, err)
+
+	_, err = r.ParamInt("not-found")
+	require.EqualError(t, err, `invalid path param "not-found": missing value`)
+
+	c, err := r.Cookie("session")
+	require.NoError(t, err)
+	require.Equal(t, "mock-session", c.Value)
+
+	_, err = r.Cookie("not-found")
+	require.EqualError(t, err, `invalid cookie param "not-found": missing value`)
+}
+
+func Test_ParamError_badRequest(t *testing.T) {
+	app := New()
+	app.GET("/users/:id", func(ctx context.Context, req Request) (interface{}, error) {
+		require.Equal(t, "abc", ParamsFromCtx(ctx).ByName("id"))
+		return req.ParamInt("id")
+	})
+
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/users/abc", nil))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.JSONEq(t, `{"message":"invalid path param \"id\": not an integer"}`, rr.Body.String())
+	require.Nil(t, ParamsFromCtx(context.Background()))
+}
@@ -0,0 +1,79 @@
+package nanny
+
+import (
+	"net/http"
+)
+
+// Response is a handler result with a status code, headers and cookies.
+// Its Body is encoded by the Encoder of the route, a nil Body means the response has no body.
+type Response struct {
+	// Status is the status code of the response.
+	// Optional. Default value is 200 if there is a Body, 204 otherwise.
+	Status int
+	// Header contains headers which are added to the response.
+	Header http.Header
+	// Cookies contains cookies which are set to the response.
+	Cookies []*http.Cookie
+	// Body is the payload of the response.
+	Body interface{}
+}
+
+// Created returns a Response with 201 status code and the Location header.
+func Created(body interface{}, location string) *Response {
+	return &Response{
+		Status: http.StatusCreated,
+		Header: http.Header{HeaderLocation: []string{location}},
+		Body:   body,
+	}
+}
+
+// Accepted returns a Response with 202 status code.
+func Accepted(body interface{}) *Response {
+	return &Response{
+		Status: http.StatusAccepted,
+		Body:   body,
+	}
+}
+
+// Redirect returns a Response redirecting to location with a 3xx status code, e.g. http.StatusFound.
+func Redirect(status int, location string) *Response {
+	return &Response{
+		Status: status,
+		Header: http.Header{HeaderLocation: []string{location}},
+	}
+}
+
+// asResponse converts a handler result to a *Response if it's a Response.
+func asResponse(resp interface{}) (*Response, bool) {
+	switch r := resp.(type) {
+	case *Response:
+		return r, r != nil
+	case Response:
+		return &r, true
+	}
+
+	return nil, false
+}
+
+// writeHeader adds headers and cookies of the response. It returns the status code of the response.
+func (r *Response) writeHeader(w http.ResponseWriter) int {
+	for k, values := range r.Header {
+		for _, v := range values {
+			w.Header().Add(k, v)
+		}
+	}
+
+	for _, c := range r.Cookies {
+		http.SetCookie(w, c)
+	}
+
+	if r.Status != 0 {
+		return r.Status
+	}
+
+	if r.Body == nil {
+		return http.StatusNoContent
+	}
+
+	return http.StatusOK
+}
@@ -0,0 +1,88 @@
+package nanny
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Response(t *testing.T) {
+	testCases := map[string]struct {
+		resp           interface{}
+		expectedCode   int
+		expectedHeader http.Header
+		expectedBody   string
+	}{
+		"created": {
+			resp:           Created(map[string]int{"id": 1}, "/users/1"),
+			expectedCode:   http.StatusCreated,
+			expectedHeader: http.Header{HeaderLocation: {"/users/1"}, HeaderContentType: {jsonScheme}},
+			expectedBody:   "{\"id\":1}\n",
+		},
+		"accepted": {
+			resp:           Accepted(nil),
+			expectedCode:   http.StatusAccepted,
+			expectedHeader: http.Header{},
+		},
+		"redirect": {
+			resp:           Redirect(http.StatusFound, "/login"),
+			expectedCode:   http.StatusFound,
+			expectedHeader: http.Header{HeaderLocation: {"/login"}},
+		},
+		"value-with-cookies": {
+			resp: Response{
+				Header:  http.Header{"X-Request-Id": {"mock-id"}},
+				Cookies: []*http.Cookie{{Name: "session", Value: "mock-session"}},
+				Body:    "mock-body",
+			},
+			expectedCode: http.StatusOK,
+			expectedHeader: http.Header{
+				"X-Request-Id":    {"mock-id"},
+				"Set-Cookie":      {"session=mock-session"},
+				HeaderContentType: {jsonScheme},
+			},
+			expectedBody: "\"mock-body\"\n",
+		},
+		"no-body": {
+			resp:           &Response{Header: http.Header{"X-Request-Id": {"mock-id"}}},
+			expectedCode:   http.StatusNoContent,
+			expectedHeader: http.Header{"X-Request-Id": {"mock-id"}},
+		},
+		"custom-body": {
+			resp:           &Response{Header: http.Header{"X-Request-Id": {"mock-id"}}, Body: notFoundErr},
+			expectedCode:   http.StatusNotFound,
+			expectedHeader: http.Header{"X-Request-Id": {"mock-id"}, HeaderContentType: {jsonScheme}},
+			expectedBody:   "{\"message\":\"Not Found\"}\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			err := defaultEncoder{}.Encode(w, tc.resp)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, w.Code)
+			require.Equal(t, tc.expectedHeader, w.Header())
+			require.Equal(t, tc.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func Test_Response_Gzip(t *testing.T) {
+	app := New(WithGzip(DefaultGzipConfig))
+	app.POST("/users", func(ctx context.Context, req Request) (interface{}, error) {
+		return Created(map[string]int{"id": 1}, "/users/1"), nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/users", nil)
+	req.Header.Set(HeaderAcceptEncoding, gzipScheme)
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Equal(t, "/users/1", w.Header().Get(HeaderLocation))
+	require.Equal(t, gzipScheme, w.Header().Get(HeaderContentEncoding))
+}
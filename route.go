@@ -1,6 +1,7 @@
 package nanny
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -33,16 +34,47 @@ type CustomHTTPResponse interface {
 type handleTransformer func(httprouter.Handle) httprouter.Handle
 
 type route struct {
-	decoder      Decoder
-	encoder      Encoder
-	errorHandler ErrorHandler
-	handler      Handler
-	logger       Logger
-	method       string
-	middlewares  []Middleware
-	path         string
-	timeout      time.Duration
-	transformers []handleTransformer
+	decoder          Decoder
+	encoder          Encoder
+	errorHandler     ErrorHandler
+	handler          Handler
+	location         string
+	logger           Logger
+	method           string
+	middlewareNames  []string
+	middlewares      []Middleware
+	mount            string
+	multipartConfig  MultipartConfig
+	name             string
+	path             string
+	sse              *SSEConfig
+	timeout          time.Duration
+	transformerNames []string
+	transformers     []handleTransformer
+	validator        Validator
+}
+
+func (app *Application) newRoute(method, path string, h Handler) *route {
+	return &route{
+		errorHandler:    defaultErrorHandler(),
+		handler:         h,
+		logger:          app.logger,
+		method:          method,
+		multipartConfig: DefaultMultipartConfig,
+		path:            path,
+	}
+}
+
+// addMiddleware adds a middleware with the name of the option which configures it, the name is reported by Routes.
+func (r *route) addMiddleware(name string, m Middleware) {
+	r.middlewareNames = append(r.middlewareNames, name)
+	r.middlewares = append(r.middlewares, m)
+}
+
+// addTransformer adds a transformer with the name of the option which configures it, the name is reported by Routes.
+func (r *route) addTransformer(name string, t handleTransformer) {
+	r.transformerNames = append(r.transformerNames, name)
+	r.transformers = append(r.transformers, t)
 }
 
 func (r *route) applyOpts(opts []RouteOption) {
@@ -59,13 +91,29 @@ func (r *route) buildHandle() httprouter.Handle {
 
 	handle := func(w http.ResponseWriter, httpReq *http.Request, params httprouter.Params) {
 		ctx := httpReq.Context()
+		if len(params) > 0 {
+			ctx = context.WithValue(ctx, ctxKeyParams, params)
+		}
+
+		if r.sse != nil {
+			ctx = context.WithValue(ctx, ctxKeyLastEventID, httpReq.Header.Get(HeaderLastEventID))
+		}
 
 		req := &requestImpl{
-			decoder: r.decoder,
-			httpReq: httpReq,
-			params:  params,
+			decoder:         r.decoder,
+			httpReq:         httpReq,
+			multipartConfig: r.multipartConfig,
+			params:          params,
+			validator:       r.validator,
 		}
 
+		// temporary files of multipart forms are removed here as http.Server only removes them from the original request
+		defer func() {
+			if httpReq.MultipartForm != nil {
+				_ = httpReq.MultipartForm.RemoveAll()
+			}
+		}()
+
 		resp, err := h(ctx, req)
 		if err != nil {
 			if errHandle := r.errorHandler(w, err); errHandle != nil {
@@ -74,7 +122,7 @@ func (r *route) buildHandle() httprouter.Handle {
 			return
 		}
 
-		if errWrite := r.encoder.Encode(w, resp); errWrite != nil {
+		if errWrite := r.encode(w, httpReq, resp); errWrite != nil {
 			r.logger.Println("Error", errWrite, "while sending response")
 		}
 	}
@@ -85,3 +133,30 @@ func (r *route) buildHandle() httprouter.Handle {
 
 	return handle
 }
+
+// encode encodes a response with the Encoder of the route. The request is passed if the Encoder is a RequestEncoder.
+// Event streams of routes with WithSSE are written as Server-Sent Events instead.
+func (r *route) encode(w http.ResponseWriter, httpReq *http.Request, resp interface{}) error {
+	if events, ok := asEventStream(resp); ok {
+		if r.sse == nil {
+			encodingErr.WriteTo(w)
+			return errSSERequired
+		}
+
+		return streamEvents(httpReq.Context(), w, events, *r.sse)
+	}
+
+	if e, ok := r.encoder.(RequestEncoder); ok {
+		return e.EncodeRequest(w, httpReq, resp)
+	}
+
+	return r.encoder.Encode(w, resp)
+}
+
+// buildHTTPHandler builds an http.Handler for the route which isn't registered with any path.
+func (r *route) buildHTTPHandler() http.Handler {
+	handle := r.buildHandle()
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		handle(w, req, nil)
+	})
+}
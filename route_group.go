@@ -12,11 +12,46 @@ type RouteGroup struct {
 	app *Application
 }
 
+// anyMethods are HTTP methods which are registered by Any.
+var anyMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+	http.MethodConnect,
+	http.MethodTrace,
+}
+
+// Handle registers a new route for a method and a path with handler.
+func (g *RouteGroup) Handle(method, path string, h Handler, opts ...RouteOption) {
+	g.addRoute(method, path, h, opts)
+}
+
+// Any registers new routes for a path with handler for all HTTP methods.
+func (g *RouteGroup) Any(path string, h Handler, opts ...RouteOption) {
+	for _, method := range anyMethods {
+		g.addRoute(method, path, h, opts)
+	}
+}
+
 // GET registers a new GET route for a path with handler.
 func (g *RouteGroup) GET(path string, h Handler, opts ...RouteOption) {
 	g.addRoute(http.MethodGet, path, h, opts)
 }
 
+// HEAD registers a new HEAD route for a path with handler.
+func (g *RouteGroup) HEAD(path string, h Handler, opts ...RouteOption) {
+	g.addRoute(http.MethodHead, path, h, opts)
+}
+
+// OPTIONS registers a new OPTIONS route for a path with handler.
+func (g *RouteGroup) OPTIONS(path string, h Handler, opts ...RouteOption) {
+	g.addRoute(http.MethodOptions, path, h, opts)
+}
+
 // POST registers a new POST route for a path with handler.
 func (g *RouteGroup) POST(path string, h Handler, opts ...RouteOption) {
 	g.addRoute(http.MethodPost, path, h, opts)
@@ -56,14 +91,8 @@ func (g *RouteGroup) Group(prefix string, opts ...RouteOption) *RouteGroup {
 }
 
 func (g *RouteGroup) addRoute(method, path string, h Handler, opts []RouteOption) {
-	r := &route{
-		errorHandler: defaultErrorHandler(),
-		handler:      h,
-		logger:       g.app.logger,
-		method:       method,
-		path:         g.prefix + path,
-	}
-
+	r := g.app.newRoute(method, g.prefix+path, h)
+	r.location = callerLocation()
 	r.applyOpts(g.routeOptions)
 	r.applyOpts(opts)
 	g.app.routes = append(g.app.routes, r)
@@ -0,0 +1,124 @@
+package nanny
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// RouteErrors contains errors of invalid routes, e.g. conflicting or duplicate routes.
+type RouteErrors []error
+
+// Error implements error interface.
+func (errs RouteErrors) Error() string {
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, "  - "+err.Error())
+	}
+
+	return "nanny: invalid routes:\n" + strings.Join(msgs, "\n")
+}
+
+// validateRoutes checks that all routes can be registered to the router.
+func (app *Application) validateRoutes() error {
+	var errs RouteErrors
+	router := httprouter.New()
+	registered := map[string]*route{}
+	named := map[string]*route{}
+	var valid []*route
+
+	for _, r := range app.routes {
+		if r.name != "" {
+			if prev, found := named[r.name]; found && routeKey(prev) != routeKey(r) {
+				errs = append(errs, fmt.Errorf("duplicate route name %q for %s %s registered at %s and %s %s registered at %s",
+					r.name, prev.method, prev.path, prev.location, r.method, r.path, r.location))
+			} else if !found {
+				named[r.name] = r
+			}
+		}
+
+		key := r.method + " " + r.path
+		if prev, found := registered[key]; found {
+			errs = append(errs, fmt.Errorf("duplicate route %s registered at %s and %s", key, prev.location, r.location))
+			continue
+		}
+		registered[key] = r
+
+		if msg := tryHandle(router, r); msg != "" {
+			errs = append(errs, conflictError(valid, r, msg))
+			continue
+		}
+
+		valid = append(valid, r)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// routeKey identifies routes which are considered a single route by their names.
+// Routes of all methods for a path, e.g. registered by Any, and routes of a Mount share a key.
+func routeKey(r *route) string {
+	if r.mount != "" {
+		return "mount " + r.mount
+	}
+
+	return r.path
+}
+
+// conflictError finds the route which conflicts with r to report.
+func conflictError(routes []*route, r *route, msg string) error {
+	if msg := tryHandle(httprouter.New(), r); msg != "" {
+		return fmt.Errorf("route %s %s registered at %s is invalid: %s", r.method, r.path, r.location, msg)
+	}
+
+	for _, prev := range routes {
+		if prev.method != r.method {
+			continue
+		}
+
+		router := httprouter.New()
+		if tryHandle(router, prev) == "" && tryHandle(router, r) != "" {
+			return fmt.Errorf("route %s %s registered at %s conflicts with route %s %s registered at %s",
+				r.method, r.path, r.location, prev.method, prev.path, prev.location)
+		}
+	}
+
+	return fmt.Errorf("route %s %s registered at %s is invalid: %s", r.method, r.path, r.location, msg)
+}
+
+// tryHandle registers a route to the router and returns the panic message if any.
+func tryHandle(router *httprouter.Router, r *route) (msg string) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			msg = fmt.Sprint(rec)
+		}
+	}()
+
+	router.Handle(r.method, r.path, func(_ http.ResponseWriter, _ *http.Request, _ httprouter.Params) {})
+	return ""
+}
+
+// callerLocation returns file:line of the code which registers a route, i.e. the first caller outside RouteGroup.
+func callerLocation() string {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.Contains(frame.Function, ".(*RouteGroup).") {
+			return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		}
+
+		if !more {
+			return "unknown"
+		}
+	}
+}
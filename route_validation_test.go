@@ -0,0 +1,63 @@
+package nanny
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateRoutes_conflict(t *testing.T) {
+	app := New()
+	app.GET("/users/:id", mockHandler)
+	app.GET("/users/new", mockHandler)
+	app.POST("/users/new", mockHandler)
+
+	err := app.Run()
+	require.EqualError(t, err, "nanny: invalid routes:\n"+
+		"  - route GET /users/new registered at route_validation_test.go:12 conflicts with route GET /users/:id registered at route_validation_test.go:11")
+	require.Panics(t, func() {
+		_ = app.Handler()
+	})
+}
+
+func Test_validateRoutes_duplicate(t *testing.T) {
+	app := New()
+	app.GET("/users", mockHandler)
+	v1 := app.Group("/v1")
+	v1.GET("/users", mockHandler)
+	app.GET("/v1/users", mockHandler)
+	app.GET("invalid", mockHandler)
+
+	err := app.validateRoutes()
+	require.Error(t, err)
+	require.IsType(t, RouteErrors{}, err)
+	errs := err.(RouteErrors)
+	require.Len(t, errs, 2)
+	require.EqualError(t, errs[0], "duplicate route GET /v1/users registered at route_validation_test.go:27 and route_validation_test.go:28")
+	require.EqualError(t, errs[1], "route GET invalid registered at route_validation_test.go:29 is invalid: path must begin with '/' in path 'invalid'")
+}
+
+func Test_validateRoutes_valid(t *testing.T) {
+	app := New()
+	app.GET("/users/:id", mockHandler)
+	app.POST("/users/new", mockHandler)
+	app.StaticFS("/static", mockFS)
+
+	require.NoError(t, app.validateRoutes())
+	require.Equal(t, "route_validation_test.go:44", app.routes[len(app.routes)-1].location)
+}
+
+func Test_validateRoutes_duplicateNames(t *testing.T) {
+	app := New()
+	app.GET("/users", mockHandler, WithName("users"))
+	app.POST("/users", mockHandler, WithName("users"))
+	app.Any("/items", mockHandler, WithName("items"))
+	app.Mount("/legacy", nil, WithName("legacy"))
+	app.GET("/v2/users", mockHandler, WithName("users"))
+
+	err := app.validateRoutes()
+	require.Error(t, err)
+	errs := err.(RouteErrors)
+	require.Len(t, errs, 1)
+	require.EqualError(t, errs[0], `duplicate route name "users" for GET /users registered at route_validation_test.go:52 and GET /v2/users registered at route_validation_test.go:56`)
+}
@@ -0,0 +1,122 @@
+package nanny
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// RouteInfo describes a registered route.
+type RouteInfo struct {
+	// Method is the HTTP method of the route.
+	Method string
+	// Path is the full path of the route including prefixes of groups.
+	Path string
+	// Name is the name of the route which is specified via WithName.
+	Name string
+	// Timeout is the time limit of the route.
+	Timeout time.Duration
+	// Middlewares are names of options which add middlewares to the route from the outermost one, e.g. nanny.WithGzip.
+	// Middlewares injected by nanny for every route are omitted.
+	Middlewares []string
+}
+
+// String returns a human-readable description of the route.
+func (info RouteInfo) String() string {
+	s := fmt.Sprintf("%-7s %s", info.Method, info.Path)
+	if info.Name != "" {
+		s += " name=" + info.Name
+	}
+
+	if info.Timeout > 0 {
+		s += " timeout=" + info.Timeout.String()
+	}
+
+	if len(info.Middlewares) > 0 {
+		s += " middlewares=" + strings.Join(info.Middlewares, ",")
+	}
+
+	return s
+}
+
+// WithName specifies the name of a route.
+func WithName(name string) RouteOptionFn {
+	return func(r *route) {
+		r.name = name
+	}
+}
+
+// WithRouteLogging logs the route table when the application starts.
+func WithRouteLogging() OptionFn {
+	return func(app *Application) {
+		app.routeLogging = true
+	}
+}
+
+// Routes returns all registered routes in the order they are registered.
+func (app *Application) Routes() []RouteInfo {
+	infos := make([]RouteInfo, 0, len(app.routes))
+	for _, r := range app.routes {
+		infos = append(infos, r.info())
+	}
+
+	return infos
+}
+
+func (app *Application) logRoutes() {
+	if !app.routeLogging {
+		return
+	}
+
+	for _, info := range app.Routes() {
+		app.logger.Println("Route", info)
+	}
+}
+
+func (r *route) info() RouteInfo {
+	info := RouteInfo{
+		Method:  r.method,
+		Path:    r.path,
+		Name:    r.name,
+		Timeout: r.timeout,
+	}
+
+	// transformers wrap middlewares, so they are listed first
+	info.Middlewares = append(info.Middlewares, r.transformerNames...)
+	info.Middlewares = append(info.Middlewares, r.middlewareNames...)
+	return info
+}
+
+// funcName returns the name of a function in the form of package.Function.
+// Closures are named after the function which creates them.
+func funcName(fn interface{}) string {
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	if idx := strings.LastIndex(name, "/"); idx >= 0 {
+		name = name[idx+1:]
+	}
+
+	// strip suffixes of closures like .func1.2
+	parts := strings.Split(name, ".")
+	for len(parts) > 2 && isClosureSuffix(parts[len(parts)-1]) {
+		parts = parts[:len(parts)-1]
+	}
+
+	return strings.Join(parts, ".")
+}
+
+func isClosureSuffix(s string) bool {
+	s = strings.TrimPrefix(s, "func")
+	if s == "" {
+		return false
+	}
+
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,65 @@
+package nanny
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithName(t *testing.T) {
+	r := &route{}
+	WithName("user.show")(r)
+	require.Equal(t, "user.show", r.name)
+}
+
+func Test_Routes(t *testing.T) {
+	app := New(WithRecovery(), WithTimeout(time.Second))
+	app.GET("/users/:id", mockHandler, WithName("user.show"))
+	v1 := app.Group("/v1", WithGzip(DefaultGzipConfig))
+	v1.POST("/users", mockHandler, WithCORS(DefaultCORSConfig), WithBodyLimit(1<<20))
+
+	require.Equal(t, []RouteInfo{
+		{
+			Method:      http.MethodGet,
+			Path:        "/users/:id",
+			Name:        "user.show",
+			Timeout:     time.Second,
+			Middlewares: []string{"nanny.WithRecovery"},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/v1/users",
+			Timeout:     time.Second,
+			Middlewares: []string{"nanny.WithGzip", "nanny.WithBodyLimit", "nanny.WithRecovery", "nanny.WithCORS"},
+		},
+	}, app.Routes())
+}
+
+func Test_RouteInfo_String(t *testing.T) {
+	info := RouteInfo{
+		Method:      http.MethodGet,
+		Path:        "/users/:id",
+		Name:        "user.show",
+		Timeout:     time.Second,
+		Middlewares: []string{"nanny.WithRecovery"},
+	}
+	require.Equal(t, "GET     /users/:id name=user.show timeout=1s middlewares=nanny.WithRecovery", info.String())
+}
+
+func Test_WithRouteLogging(t *testing.T) {
+	var b bytes.Buffer
+	app := New(WithLogger(log.New(&b, "", 0)), WithRouteLogging())
+	app.GET("/users/:id", mockHandler)
+	app.logRoutes()
+	require.Equal(t, "Route GET     /users/:id\n", b.String())
+}
+
+func Test_funcName(t *testing.T) {
+	require.Equal(t, "nanny.WithRecovery", funcName(WithRecovery()))
+	require.Equal(t, "nanny.mockHandler", funcName(mockHandler))
+	require.Equal(t, "nanny.Test_funcName", funcName(func() {}))
+}
@@ -0,0 +1,58 @@
+package nanny
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/netutil"
+)
+
+// ServerConfig defines the config for HTTP servers of the application.
+type ServerConfig struct {
+	// ReadTimeout is the maximum duration for reading the entire request, including the body.
+	ReadTimeout time.Duration
+	// ReadHeaderTimeout is the amount of time allowed to read request headers.
+	ReadHeaderTimeout time.Duration
+	// WriteTimeout is the maximum duration before timing out writes of the response.
+	WriteTimeout time.Duration
+	// IdleTimeout is the maximum amount of time to wait for the next request when keep-alives are enabled.
+	IdleTimeout time.Duration
+	// MaxHeaderBytes controls the maximum number of bytes the server will read parsing the request header.
+	MaxHeaderBytes int
+	// MaxConns limits the number of concurrent connections on each listener.
+	// Optional. Default value 0 means no limit.
+	MaxConns int
+	// DisableKeepAlives disables HTTP keep-alives.
+	DisableKeepAlives bool
+}
+
+// DefaultServerConfig is the default config for HTTP servers.
+var DefaultServerConfig = ServerConfig{
+	ReadHeaderTimeout: 10 * time.Second,
+	IdleTimeout:       2 * time.Minute,
+}
+
+// WithServerConfig specifies the config for HTTP servers. It's applied to both the main server and the pprof server.
+func WithServerConfig(cfg ServerConfig) OptionFn {
+	return func(app *Application) {
+		app.serverConfig = cfg
+	}
+}
+
+func (cfg ServerConfig) applyServer(srv *http.Server) {
+	srv.ReadTimeout = cfg.ReadTimeout
+	srv.ReadHeaderTimeout = cfg.ReadHeaderTimeout
+	srv.WriteTimeout = cfg.WriteTimeout
+	srv.IdleTimeout = cfg.IdleTimeout
+	srv.MaxHeaderBytes = cfg.MaxHeaderBytes
+	srv.SetKeepAlivesEnabled(!cfg.DisableKeepAlives)
+}
+
+func (cfg ServerConfig) limitListener(ln net.Listener) net.Listener {
+	if cfg.MaxConns <= 0 {
+		return ln
+	}
+
+	return netutil.LimitListener(ln, cfg.MaxConns)
+}
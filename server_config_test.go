@@ -0,0 +1,72 @@
+package nanny
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithServerConfig(t *testing.T) {
+	cfg := ServerConfig{
+		ReadTimeout:       time.Second,
+		ReadHeaderTimeout: 2 * time.Second,
+		WriteTimeout:      3 * time.Second,
+		IdleTimeout:       4 * time.Second,
+		MaxHeaderBytes:    1 << 10,
+		MaxConns:          10,
+	}
+	app := New(WithServerConfig(cfg))
+	require.Equal(t, cfg, app.serverConfig)
+}
+
+func Test_ServerConfig_applyServer(t *testing.T) {
+	srv := &http.Server{}
+	DefaultServerConfig.applyServer(srv)
+	require.Equal(t, 10*time.Second, srv.ReadHeaderTimeout)
+	require.Equal(t, 2*time.Minute, srv.IdleTimeout)
+}
+
+func Test_ServerConfig_limitListener(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	require.Equal(t, ln, ServerConfig{}.limitListener(ln), "no limit by default")
+
+	limited := ServerConfig{MaxConns: 1}.limitListener(ln)
+	require.NotEqual(t, ln, limited)
+
+	conn1, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	defer conn1.Close()
+	conn2, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	defer conn2.Close()
+
+	accepted, err := limited.Accept()
+	require.NoError(t, err)
+
+	secondAccepted := make(chan struct{})
+	go func() {
+		if c, err := limited.Accept(); err == nil {
+			_ = c.Close()
+		}
+		close(secondAccepted)
+	}()
+
+	select {
+	case <-secondAccepted:
+		require.Fail(t, "The second connection must wait for the first one to be closed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	require.NoError(t, accepted.Close())
+	select {
+	case <-secondAccepted:
+	case <-time.After(time.Second):
+		require.Fail(t, "The second connection must be accepted")
+	}
+}
@@ -0,0 +1,120 @@
+package nanny
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// WithShutdownTimeout specifies the time limit for draining in-flight requests during graceful shutdown.
+// Remaining connections are closed forcefully once the deadline passes. The limit applies to the pprof server as well.
+// There is no time limit by default.
+func WithShutdownTimeout(timeout time.Duration) OptionFn {
+	return func(app *Application) {
+		app.shutdownTimeout = timeout
+	}
+}
+
+// WithShutdownDelay specifies how long the application keeps serving after a shutdown is requested.
+// It gives load balancers time to deregister the application before connections are drained.
+// The delay applies to shutdowns by signals or context cancellation but not to shutdowns caused by fatal errors.
+func WithShutdownDelay(delay time.Duration) OptionFn {
+	return func(app *Application) {
+		app.shutdownDelay = delay
+	}
+}
+
+// WithShutdownSignals specifies the signals which trigger graceful shutdown.
+// By default, the application is shut down by os.Interrupt and syscall.SIGTERM.
+func WithShutdownSignals(signals ...os.Signal) OptionFn {
+	return func(app *Application) {
+		app.shutdownSignals = signals
+	}
+}
+
+func defaultShutdownSignals() []os.Signal {
+	return []os.Signal{os.Interrupt, syscall.SIGTERM}
+}
+
+// setupGracefulShutdown starts a goroutine for interrupt signal and proceed with graceful shutdown.
+// The application is also shut down when ctx is cancelled.
+func (app *Application) setupGracefulShutdown(ctx context.Context) {
+	app.execute(func() {
+		sigCh := make(chan os.Signal, 1)
+		if len(app.shutdownSignals) > 0 {
+			signal.Notify(sigCh, app.shutdownSignals...)
+			defer signal.Stop(sigCh)
+		}
+
+		// the delay only applies to requested shutdowns, not to shutdowns caused by fatal errors
+		requested := false
+		select {
+		case sig := <-sigCh:
+			app.logger.Println("Received signal", sig, "shutting down")
+			requested = true
+		case <-ctx.Done():
+			requested = true
+		case <-app.shutdownSignal:
+		}
+
+		app.shutdown()
+
+		if requested && app.shutdownDelay > 0 {
+			app.logger.Println("Waiting", app.shutdownDelay, "before draining connections")
+			time.Sleep(app.shutdownDelay)
+		}
+
+		app.execute(func() {
+			app.shutdownServer(app.srv, "HTTP")
+		})
+
+		if app.pprofSrv != nil {
+			app.execute(func() {
+				app.shutdownServer(app.pprofSrv, "pprof")
+			})
+		}
+	})
+}
+
+// shutdownServer drains a server and closes it forcefully if the drain deadline passes.
+func (app *Application) shutdownServer(srv *http.Server, name string) {
+	ctx := context.Background()
+	if app.shutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, app.shutdownTimeout)
+		defer cancel()
+	}
+
+	err := srv.Shutdown(ctx)
+	if err == nil || err == http.ErrServerClosed {
+		return
+	}
+
+	if err == context.DeadlineExceeded {
+		if srv == app.srv {
+			app.logger.Println("Shutdown timed out,", atomic.LoadInt64(&app.inFlight), "in-flight requests were cut off")
+		} else {
+			app.logger.Println("Shutdown of", name, "server timed out, in-flight requests were cut off")
+		}
+		err = srv.Close()
+		if err == nil {
+			return
+		}
+	}
+
+	app.fail(fmt.Errorf("nanny: error when shutting down %s server: %w", name, err))
+}
+
+// trackInFlight counts requests which are being served.
+func (app *Application) trackInFlight(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt64(&app.inFlight, 1)
+		defer atomic.AddInt64(&app.inFlight, -1)
+		next.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,145 @@
+package nanny
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithShutdownTimeout(t *testing.T) {
+	app := New(WithShutdownTimeout(time.Second))
+	require.Equal(t, time.Second, app.shutdownTimeout)
+}
+
+func Test_WithShutdownDelay(t *testing.T) {
+	app := New(WithShutdownDelay(time.Second))
+	require.Equal(t, time.Second, app.shutdownDelay)
+}
+
+func Test_WithShutdownDelay_fatalError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	app := New(
+		WithAddress(ln.Addr().String()),
+		WithShutdownDelay(10*time.Second),
+		WithShutdownSignals(),
+	)
+
+	start := time.Now()
+	err = app.Run()
+	require.Error(t, err)
+	require.Less(t, int64(time.Since(start)), int64(5*time.Second))
+}
+
+func Test_WithShutdownSignals(t *testing.T) {
+	app := New()
+	require.Equal(t, []os.Signal{os.Interrupt, syscall.SIGTERM}, app.shutdownSignals)
+
+	WithShutdownSignals(syscall.SIGHUP)(app)
+	require.Equal(t, []os.Signal{syscall.SIGHUP}, app.shutdownSignals)
+}
+
+func Test_shutdown_forceClose(t *testing.T) {
+	var b bytes.Buffer
+	addr := freeAddr(t)
+	app := New(
+		WithAddress(addr),
+		WithLogger(log.New(&b, "", 0)),
+		WithShutdownTimeout(50*time.Millisecond),
+		WithShutdownSignals(),
+	)
+
+	handlerStarted := make(chan struct{})
+	releaseHandler := make(chan struct{})
+	defer close(releaseHandler)
+	app.GET("/slow", func(ctx context.Context, req Request) (interface{}, error) {
+		close(handlerStarted)
+		<-releaseHandler
+		return nil, nil
+	})
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- app.Run()
+	}()
+	<-app.readyCh
+
+	go func() {
+		resp, err := http.Get("http://" + addr + "/slow")
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	<-handlerStarted
+
+	app.shutdown()
+	select {
+	case err := <-runErr:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		require.Fail(t, "Test times out")
+	}
+	require.Contains(t, b.String(), "1 in-flight requests were cut off")
+}
+
+// freeAddr returns a local TCP address which is available for listening.
+func freeAddr(t *testing.T) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	return ln.Addr().String()
+}
+
+func Test_shutdown_forceClosePProf(t *testing.T) {
+	var b bytes.Buffer
+	pprofAddr := freeAddr(t)
+	app := New(
+		WithAddress(freeAddr(t)),
+		WithPProf(pprofAddr),
+		WithLogger(log.New(&b, "", 0)),
+		WithShutdownTimeout(50*time.Millisecond),
+		WithShutdownSignals(),
+	)
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- app.Run()
+	}()
+	<-app.readyCh
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", pprofAddr)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, time.Second, 10*time.Millisecond)
+
+	go func() {
+		resp, err := http.Get("http://" + pprofAddr + "/debug/pprof/profile?seconds=60")
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	app.shutdown()
+	select {
+	case err := <-runErr:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "Test times out")
+	}
+	require.Contains(t, b.String(), "Shutdown of pprof server timed out")
+}
@@ -0,0 +1,174 @@
+package nanny
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const eventStreamScheme = "text/event-stream"
+
+// errSSERequired is returned when a handler returns an event stream for a route without WithSSE.
+var errSSERequired = errors.New("nanny: event streams can only be returned by routes with WithSSE")
+
+// SSEConfig defines the config for Server-Sent Events routes.
+type SSEConfig struct {
+	// KeepAlive is the interval of keep-alive comments which keep idle connections open.
+	// Optional. Default value 0 means no keep-alive comment.
+	KeepAlive time.Duration
+}
+
+// DefaultSSEConfig is the default config for Server-Sent Events routes.
+var DefaultSSEConfig = SSEConfig{
+	KeepAlive: 15 * time.Second,
+}
+
+// Event is a Server-Sent Event. Ref: https://html.spec.whatwg.org/multipage/server-sent-events.html.
+type Event struct {
+	// ID is the event ID which is sent back via the Last-Event-ID header when the client reconnects.
+	ID string
+	// Name is the event type. Optional.
+	Name string
+	// Data is the payload of the event. Strings and []byte are sent as they are, other values are encoded as JSON.
+	Data interface{}
+	// Retry is the reconnection time for the client. Optional.
+	Retry time.Duration
+}
+
+// WithSSE makes a route stream Server-Sent Events. The handler of the route returns a <-chan Event
+// and events are written and flushed until the channel is closed, the request is cancelled or the application shuts down.
+// The ID of the last received event is returned by LastEventIDFromCtx when the client reconnects.
+// The route isn't limited by WithTimeout and its responses aren't compressed by WithGzip.
+func WithSSE(cfg SSEConfig) RouteOptionFn {
+	return func(r *route) {
+		r.sse = &cfg
+		r.timeout = 0
+	}
+}
+
+// LastEventIDFromCtx returns the Last-Event-ID header of the request being served by a route with WithSSE.
+// It's the ID of the last event received by the client before reconnecting, empty for a new connection.
+func LastEventIDFromCtx(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKeyLastEventID).(string)
+	return id
+}
+
+// isSSERoute checks if the route serving the request streams Server-Sent Events.
+func isSSERoute(ctx context.Context) bool {
+	r, ok := ctx.Value(ctxKeyRoute).(*route)
+	return ok && r.sse != nil
+}
+
+// asEventStream converts a handler result to a channel of events if it's an event stream.
+func asEventStream(resp interface{}) (<-chan Event, bool) {
+	switch events := resp.(type) {
+	case <-chan Event:
+		return events, true
+	case chan Event:
+		return events, true
+	}
+
+	return nil, false
+}
+
+// streamEvents writes events to w until events is closed, ctx is cancelled or the application shuts down.
+func streamEvents(ctx context.Context, w http.ResponseWriter, events <-chan Event, cfg SSEConfig) error {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return errors.New("nanny: the response writer doesn't support flushing")
+	}
+
+	header := w.Header()
+	header.Set(HeaderContentType, eventStreamScheme)
+	header.Set(HeaderCacheControl, "no-cache")
+	header.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	var keepAlive <-chan time.Time
+	if cfg.KeepAlive > 0 {
+		ticker := time.NewTicker(cfg.KeepAlive)
+		defer ticker.Stop()
+		keepAlive = ticker.C
+	}
+
+	var shutdownSignal chan struct{}
+	if app, ok := ctx.Value(ctxKeyApp).(*Application); ok {
+		shutdownSignal = app.shutdownSignal
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-shutdownSignal:
+			return nil
+		case <-keepAlive:
+			if _, err := w.Write([]byte(": keep-alive\n\n")); err != nil {
+				return err
+			}
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			data, err := e.encode()
+			if err != nil {
+				return err
+			}
+
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+		}
+
+		flusher.Flush()
+	}
+}
+
+// encode encodes an event in the event stream format.
+func (e Event) encode() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if e.ID != "" {
+		fmt.Fprintf(buf, "id: %s\n", stripNewlines(e.ID))
+	}
+
+	if e.Name != "" {
+		fmt.Fprintf(buf, "event: %s\n", stripNewlines(e.Name))
+	}
+
+	if e.Retry > 0 {
+		fmt.Fprintf(buf, "retry: %d\n", e.Retry.Milliseconds())
+	}
+
+	var data string
+	switch d := e.Data.(type) {
+	case nil:
+	case string:
+		data = d
+	case []byte:
+		data = string(d)
+	default:
+		b, err := json.Marshal(d)
+		if err != nil {
+			return nil, err
+		}
+		data = string(b)
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		fmt.Fprintf(buf, "data: %s\n", line)
+	}
+
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+func stripNewlines(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
@@ -0,0 +1,122 @@
+package nanny
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Event_encode(t *testing.T) {
+	testCases := map[string]struct {
+		event    Event
+		expected string
+	}{
+		"string": {
+			event:    Event{ID: "1", Name: "progress", Data: "line1\nline2", Retry: time.Second},
+			expected: "id: 1\nevent: progress\nretry: 1000\ndata: line1\ndata: line2\n\n",
+		},
+		"json": {
+			event:    Event{Data: map[string]int{"percent": 50}},
+			expected: "data: {\"percent\":50}\n\n",
+		},
+		"bytes": {
+			event:    Event{ID: "a\nb", Data: []byte("raw")},
+			expected: "id: ab\ndata: raw\n\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			data, err := tc.event.encode()
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(data))
+		})
+	}
+}
+
+func Test_WithSSE(t *testing.T) {
+	app := New(WithGzip(DefaultGzipConfig), WithTimeout(10*time.Millisecond))
+	app.GET("/progress", func(ctx context.Context, req Request) (interface{}, error) {
+		events := make(chan Event)
+		go func() {
+			defer close(events)
+			for i := 1; i <= 2; i++ {
+				// longer than the timeout of the application
+				time.Sleep(20 * time.Millisecond)
+				select {
+				case events <- Event{ID: LastEventIDFromCtx(ctx) + "-" + string(rune('0'+i)), Data: "step"}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		return events, nil
+	}, WithSSE(SSEConfig{KeepAlive: 5 * time.Millisecond}))
+
+	req := httptest.NewRequest(http.MethodGet, "/progress", nil)
+	req.Header.Set(HeaderAcceptEncoding, gzipScheme)
+	req.Header.Set(HeaderLastEventID, "5")
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, eventStreamScheme, w.Header().Get(HeaderContentType))
+	require.Equal(t, "no-cache", w.Header().Get(HeaderCacheControl))
+	require.Empty(t, w.Header().Get(HeaderContentEncoding))
+	require.True(t, w.Flushed)
+
+	body := w.Body.String()
+	require.Contains(t, body, ": keep-alive\n\n")
+	require.Contains(t, body, "id: 5-1\ndata: step\n\n")
+	require.Contains(t, body, "id: 5-2\ndata: step\n\n")
+	require.Less(t, strings.Index(body, "id: 5-1"), strings.Index(body, "id: 5-2"))
+}
+
+func Test_WithSSE_Cancel(t *testing.T) {
+	app := New()
+	app.GET("/events", func(ctx context.Context, req Request) (interface{}, error) {
+		return make(<-chan Event), nil
+	}, WithSSE(DefaultSSEConfig))
+
+	t.Run("context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events", nil).WithContext(ctx))
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("shutdown", func(t *testing.T) {
+		time.AfterFunc(10*time.Millisecond, app.shutdown)
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func Test_EventStream_withoutWithSSE(t *testing.T) {
+	app := New(WithGzip(DefaultGzipConfig))
+	app.GET("/events", func(ctx context.Context, req Request) (interface{}, error) {
+		require.Empty(t, LastEventIDFromCtx(ctx))
+		events := make(chan Event)
+		close(events)
+		return events, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	req.Header.Set(HeaderAcceptEncoding, gzipScheme)
+	req.Header.Set(HeaderLastEventID, "5")
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.NotEqual(t, eventStreamScheme, w.Header().Get(HeaderContentType))
+}
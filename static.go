@@ -0,0 +1,156 @@
+package nanny
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	staticPathParam = "filepath"
+	htmlScheme      = "text/html; charset=utf-8"
+)
+
+// StaticConfig defines the config for serving static files.
+type StaticConfig struct {
+	// Root is the file system to serve files from.
+	Root http.FileSystem
+	// Index is the file which is served for directories.
+	// Optional. Default value "index.html".
+	Index string
+	// Browse enables directory listing for directories without the index file.
+	// Optional. Default value false.
+	Browse bool
+	// SPA serves the index file of Root for unknown paths, e.g. for single-page applications.
+	// Optional. Default value false.
+	SPA bool
+}
+
+// Static serves static files from root under prefix.
+// Range requests and conditional requests via If-Modified-Since and ETag are supported.
+func (g *RouteGroup) Static(prefix string, root http.FileSystem, opts ...RouteOption) {
+	g.StaticWithConfig(prefix, StaticConfig{Root: root}, opts...)
+}
+
+// StaticFS serves static files from fsys under prefix. It supports embed.FS.
+func (g *RouteGroup) StaticFS(prefix string, fsys fs.FS, opts ...RouteOption) {
+	g.Static(prefix, http.FS(fsys), opts...)
+}
+
+// StaticWithConfig serves static files under prefix with a custom config.
+func (g *RouteGroup) StaticWithConfig(prefix string, cfg StaticConfig, opts ...RouteOption) {
+	if cfg.Index == "" {
+		cfg.Index = "index.html"
+	}
+
+	p := strings.TrimSuffix(prefix, "/") + "/*" + staticPathParam
+	h := staticHandler(cfg)
+	g.GET(p, h, opts...)
+	g.HEAD(p, h, opts...)
+}
+
+func staticHandler(cfg StaticConfig) Handler {
+	return func(ctx context.Context, req Request) (interface{}, error) {
+		name := path.Clean("/" + req.Param(staticPathParam))
+		resp, err := openStaticFile(cfg, name, req.HTTPRequest())
+		if err == nil || !cfg.SPA {
+			return resp, err
+		}
+
+		return openStaticFile(cfg, "/"+cfg.Index, req.HTTPRequest())
+	}
+}
+
+// openStaticFile opens a file or the index file of a directory to serve.
+func openStaticFile(cfg StaticConfig, name string, httpReq *http.Request) (interface{}, error) {
+	f, err := cfg.Root.Open(name)
+	if err != nil {
+		return nil, notFoundErr
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, notFoundErr
+	}
+
+	if !info.IsDir() {
+		return &staticFile{file: f, info: info, httpReq: httpReq}, nil
+	}
+
+	index, err := cfg.Root.Open(path.Join(name, cfg.Index))
+	if err == nil {
+		indexInfo, err := index.Stat()
+		if err == nil && !indexInfo.IsDir() {
+			_ = f.Close()
+			return &staticFile{file: index, info: indexInfo, httpReq: httpReq}, nil
+		}
+		_ = index.Close()
+	}
+
+	if !cfg.Browse {
+		_ = f.Close()
+		return nil, notFoundErr
+	}
+
+	return &dirListing{dir: f, httpReq: httpReq}, nil
+}
+
+// staticFile is a CustomHTTPResponse to serve a file.
+type staticFile struct {
+	file    http.File
+	info    fs.FileInfo
+	httpReq *http.Request
+}
+
+// WriteTo implements CustomHTTPResponse.
+func (sf *staticFile) WriteTo(w http.ResponseWriter) {
+	defer sf.file.Close()
+
+	w.Header().Set("ETag", fmt.Sprintf(`W/"%x-%x"`, sf.info.ModTime().UnixNano(), sf.info.Size()))
+	http.ServeContent(w, sf.httpReq, sf.info.Name(), sf.info.ModTime(), sf.file)
+}
+
+// dirListing is a CustomHTTPResponse to list files in a directory.
+type dirListing struct {
+	dir     http.File
+	httpReq *http.Request
+}
+
+// WriteTo implements CustomHTTPResponse.
+func (dl *dirListing) WriteTo(w http.ResponseWriter) {
+	defer dl.dir.Close()
+
+	files, err := dl.dir.Readdir(-1)
+	if err != nil {
+		notFoundErr.WriteTo(w)
+		return
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Name() < files[j].Name() })
+
+	w.Header().Set(HeaderContentType, htmlScheme)
+	w.WriteHeader(http.StatusOK)
+	if dl.httpReq.Method == http.MethodHead {
+		return
+	}
+
+	base := strings.TrimSuffix(dl.httpReq.URL.Path, "/") + "/"
+	fmt.Fprintf(w, "<pre>\n")
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() {
+			name += "/"
+		}
+
+		link := url.URL{Path: base + name}
+		fmt.Fprintf(w, "<a href=\"%s\">%s</a>\n", html.EscapeString(link.String()), html.EscapeString(name))
+	}
+	fmt.Fprintf(w, "</pre>\n")
+}
@@ -0,0 +1,136 @@
+package nanny
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var mockFS = fstest.MapFS{
+	"index.html":        {Data: []byte("<h1>Home</h1>"), ModTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+	"css/app.css":       {Data: []byte("body { color: red; }"), ModTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+	"docs/guide.txt":    {Data: []byte("guide")},
+	"docs/a&b.txt":      {Data: []byte("a&b")},
+	"blog/index.html":   {Data: []byte("<h1>Blog</h1>")},
+	"blog/post-1.html":  {Data: []byte("<h1>Post 1</h1>")},
+	"images/.gitignore": {Data: []byte("")},
+}
+
+func serveStatic(app *Application, req *http.Request) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, req)
+	return rr
+}
+
+func Test_StaticFS(t *testing.T) {
+	app := New(WithGzip(DefaultGzipConfig))
+	app.StaticFS("/static", mockFS)
+
+	t.Run("file", func(t *testing.T) {
+		rr := serveStatic(app, httptest.NewRequest(http.MethodGet, "/static/css/app.css", nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, "body { color: red; }", rr.Body.String())
+		require.Equal(t, "text/css; charset=utf-8", rr.Header().Get(HeaderContentType))
+		require.NotEmpty(t, rr.Header().Get("ETag"))
+		require.NotEmpty(t, rr.Header().Get("Last-Modified"))
+	})
+
+	t.Run("head", func(t *testing.T) {
+		rr := serveStatic(app, httptest.NewRequest(http.MethodHead, "/static/css/app.css", nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Empty(t, rr.Body.String())
+	})
+
+	t.Run("index", func(t *testing.T) {
+		rr := serveStatic(app, httptest.NewRequest(http.MethodGet, "/static/", nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, "<h1>Home</h1>", rr.Body.String())
+
+		rr = serveStatic(app, httptest.NewRequest(http.MethodGet, "/static/blog", nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, "<h1>Blog</h1>", rr.Body.String())
+	})
+
+	t.Run("range", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/static/css/app.css", nil)
+		req.Header.Set("Range", "bytes=0-3")
+		rr := serveStatic(app, req)
+		require.Equal(t, http.StatusPartialContent, rr.Code)
+		require.Equal(t, "body", rr.Body.String())
+	})
+
+	t.Run("if-modified-since", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/static/css/app.css", nil)
+		req.Header.Set("If-Modified-Since", time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat))
+		rr := serveStatic(app, req)
+		require.Equal(t, http.StatusNotModified, rr.Code)
+	})
+
+	t.Run("etag", func(t *testing.T) {
+		rr := serveStatic(app, httptest.NewRequest(http.MethodGet, "/static/css/app.css", nil))
+		req := httptest.NewRequest(http.MethodGet, "/static/css/app.css", nil)
+		req.Header.Set("If-None-Match", rr.Header().Get("ETag"))
+		rr = serveStatic(app, req)
+		require.Equal(t, http.StatusNotModified, rr.Code)
+	})
+
+	t.Run("no-directory-listing", func(t *testing.T) {
+		rr := serveStatic(app, httptest.NewRequest(http.MethodGet, "/static/docs/", nil))
+		require.Equal(t, http.StatusNotFound, rr.Code)
+		require.JSONEq(t, `{"message":"Not Found"}`, rr.Body.String())
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		rr := serveStatic(app, httptest.NewRequest(http.MethodGet, "/static/not-found.js", nil))
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("path-traversal", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/static/css/app.css", nil)
+		req.URL.Path = "/static/../../etc/passwd"
+		rr := serveStatic(app, req)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("gzip", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/static/css/app.css", nil)
+		req.Header.Set(HeaderAcceptEncoding, gzipScheme)
+		rr := serveStatic(app, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, gzipScheme, rr.Header().Get(HeaderContentEncoding))
+		require.Equal(t, "body { color: red; }", decodeGzip(rr.Body))
+	})
+}
+
+func Test_StaticWithConfig_browse(t *testing.T) {
+	app := New()
+	app.StaticWithConfig("/", StaticConfig{
+		Root:   http.FS(mockFS),
+		Browse: true,
+	})
+
+	rr := serveStatic(app, httptest.NewRequest(http.MethodGet, "/docs/", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, htmlScheme, rr.Header().Get(HeaderContentType))
+	require.Equal(t, "<pre>\n<a href=\"/docs/a&amp;b.txt\">a&amp;b.txt</a>\n<a href=\"/docs/guide.txt\">guide.txt</a>\n</pre>\n", rr.Body.String())
+}
+
+func Test_StaticWithConfig_SPA(t *testing.T) {
+	app := New()
+	app.StaticWithConfig("/app", StaticConfig{
+		Root: http.FS(mockFS),
+		SPA:  true,
+	})
+
+	rr := serveStatic(app, httptest.NewRequest(http.MethodGet, "/app/users/1", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "<h1>Home</h1>", rr.Body.String())
+
+	rr = serveStatic(app, httptest.NewRequest(http.MethodGet, "/app/blog/post-1.html", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "<h1>Post 1</h1>", rr.Body.String())
+}
@@ -15,7 +15,7 @@ func WithTimeout(timeout time.Duration) RouteOptionFn {
 func injectTimeoutMiddleware() RouteOptionFn {
 	return func(r *route) {
 		var m Middleware = func(next Handler) Handler {
-			if r.timeout == 0 {
+			if r.timeout == 0 || r.sse != nil {
 				return next
 			}
 
@@ -0,0 +1,160 @@
+package nanny
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// WithTLS serves HTTPS with HTTP/2 using a certificate and a private key from files.
+// The certificate is reloaded without restarting when the files change.
+func WithTLS(certFile, keyFile string) OptionFn {
+	return func(app *Application) {
+		reloader := &certReloader{
+			certFile: certFile,
+			keyFile:  keyFile,
+		}
+
+		app.tlsConfig = &tls.Config{
+			GetCertificate: reloader.GetCertificate,
+		}
+
+		// the certificate is loaded before serving so that invalid files are reported by Run.
+		app.OnStart(func(_ context.Context) error {
+			return reloader.reload()
+		})
+	}
+}
+
+// WithTLSConfig serves HTTPS with HTTP/2 using a custom tls.Config.
+func WithTLSConfig(cfg *tls.Config) OptionFn {
+	return func(app *Application) {
+		app.tlsConfig = cfg
+	}
+}
+
+// WithH2C enables HTTP/2 over plaintext TCP connections, e.g. for serving behind a service mesh.
+// It has no effect if TLS is enabled.
+func WithH2C() OptionFn {
+	return func(app *Application) {
+		app.h2c = true
+	}
+}
+
+// serve accepts incoming connections on the listener and serves them using HTTP or HTTPS.
+func (app *Application) serve(srv *http.Server, ln net.Listener) error {
+	if app.tlsConfig != nil {
+		return srv.ServeTLS(ln, "", "")
+	}
+
+	return srv.Serve(ln)
+}
+
+// wrapH2C adds the support of HTTP/2 over plaintext connections to the handler if it's enabled.
+func (app *Application) wrapH2C(h http.Handler) http.Handler {
+	if !app.h2c || app.tlsConfig != nil {
+		return h
+	}
+
+	return h2c.NewHandler(h, &http2.Server{})
+}
+
+// certCheckInterval is the minimum interval between checks of certificate files for modifications.
+var certCheckInterval = time.Second
+
+// certReloader loads a certificate from files and reloads it when the files are modified.
+// Files are checked at most once per certCheckInterval to avoid calling os.Stat on every handshake.
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+// GetCertificate implements tls.Config.GetCertificate.
+func (r *certReloader) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	cert, loadedAt, checkedAt := r.cert, r.modTime, r.checkedAt
+	r.mu.RUnlock()
+
+	if cert != nil && time.Since(checkedAt) < certCheckInterval {
+		return cert, nil
+	}
+
+	r.mu.Lock()
+	r.checkedAt = time.Now()
+	r.mu.Unlock()
+
+	modTime, err := r.lastModified()
+	if err != nil {
+		if cert != nil {
+			return cert, nil
+		}
+
+		return nil, err
+	}
+
+	if cert != nil && !modTime.After(loadedAt) {
+		return cert, nil
+	}
+
+	if err := r.reload(); err != nil {
+		// keep serving the previous certificate if the new one is invalid, e.g. only one file is updated.
+		if cert != nil {
+			return cert, nil
+		}
+
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+func (r *certReloader) reload() error {
+	modTime, err := r.lastModified()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("nanny: error when loading certificate: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.modTime = modTime
+	r.checkedAt = time.Now()
+	return nil
+}
+
+// lastModified returns the latest modification time of the certificate and the key.
+func (r *certReloader) lastModified() (time.Time, error) {
+	var modTime time.Time
+	for _, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("nanny: error when loading certificate: %w", err)
+		}
+
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+
+	return modTime, nil
+}
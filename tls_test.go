@@ -0,0 +1,165 @@
+package nanny
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+)
+
+// writeSelfSignedCert generates a self-signed certificate for localhost and writes it to files.
+func writeSelfSignedCert(t *testing.T, certFile, keyFile, commonName string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+}
+
+// runApp runs the app in background. The returned function stops the app.
+func runApp(t *testing.T, app *Application) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- app.RunContext(ctx)
+	}()
+
+	select {
+	case <-app.readyCh:
+	case err := <-runErr:
+		require.FailNow(t, "App stops unexpectedly", "%v", err)
+	case <-time.After(time.Second):
+		require.FailNow(t, "App isn't ready in time")
+	}
+
+	return func() {
+		cancel()
+		require.NoError(t, <-runErr)
+	}
+}
+
+func Test_WithTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nanny-tls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	writeSelfSignedCert(t, certFile, keyFile, "first")
+
+	addr := freeAddr(t)
+	app := New(WithAddress(addr), WithTLS(certFile, keyFile), WithShutdownSignals())
+	app.GET("/mock-endpoint", func(ctx context.Context, req Request) (interface{}, error) {
+		return "OK", nil
+	})
+	defer runApp(t, app)()
+
+	get := func() *http.Response {
+		client := &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+				ForceAttemptHTTP2: true,
+			},
+		}
+		resp, err := client.Get("https://" + addr + "/mock-endpoint")
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		return resp
+	}
+
+	resp := get()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "HTTP/2.0", resp.Proto)
+	require.Equal(t, "first", resp.TLS.PeerCertificates[0].Subject.CommonName)
+
+	writeSelfSignedCert(t, certFile, keyFile, "second")
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, future, future))
+
+	// files are checked at most once per certCheckInterval
+	require.Eventually(t, func() bool {
+		return get().TLS.PeerCertificates[0].Subject.CommonName == "second"
+	}, 3*certCheckInterval, 100*time.Millisecond)
+}
+
+func Test_WithTLS_invalidFiles(t *testing.T) {
+	app := New(WithAddress("127.0.0.1:0"), WithTLS("not-found.pem", "not-found.key"))
+	err := app.Run()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error when loading certificate")
+}
+
+func Test_WithTLSConfig(t *testing.T) {
+	cfg := &tls.Config{}
+	app := New(WithTLSConfig(cfg))
+	require.Equal(t, cfg, app.tlsConfig)
+}
+
+func Test_WithH2C(t *testing.T) {
+	addr := freeAddr(t)
+	app := New(WithAddress(addr), WithH2C(), WithShutdownSignals())
+	app.GET("/mock-endpoint", func(ctx context.Context, req Request) (interface{}, error) {
+		return "OK", nil
+	})
+	defer runApp(t, app)()
+
+	client := &http.Client{
+		Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+				return net.Dial(network, addr)
+			},
+		},
+	}
+	resp, err := client.Get("http://" + addr + "/mock-endpoint")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "HTTP/2.0", resp.Proto)
+}
+
+func Test_certReloader_throttle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nanny-tls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	writeSelfSignedCert(t, certFile, keyFile, "first")
+	reloader := &certReloader{certFile: certFile, keyFile: keyFile}
+	require.NoError(t, reloader.reload())
+
+	// removed files aren't noticed until the next check
+	require.NoError(t, os.Remove(certFile))
+	cert, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	require.NotNil(t, cert)
+}
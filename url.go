@@ -0,0 +1,93 @@
+package nanny
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// URL builds the path of a named route by filling its params.
+// Params are given as pairs of key and value, e.g. app.URL("user.show", "id", "1").
+// Values are escaped and a catch-all param may contain slashes. Named params must not be empty.
+// It returns an error if the route is not found or params don't match the route.
+func (app *Application) URL(name string, pairs ...string) (string, error) {
+	if len(pairs)%2 != 0 {
+		return "", fmt.Errorf("nanny: params of route %q must be pairs of key and value", name)
+	}
+
+	params := make(map[string]string, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		params[pairs[i]] = pairs[i+1]
+	}
+
+	for _, r := range app.routes {
+		if r.name == name {
+			return buildURL(r, params)
+		}
+	}
+
+	return "", fmt.Errorf("nanny: route %q is not found", name)
+}
+
+// URLFromCtx builds the path of a named route using the Application serving the request.
+// See Application.URL for details.
+func URLFromCtx(ctx context.Context, name string, pairs ...string) (string, error) {
+	app, ok := ctx.Value(ctxKeyApp).(*Application)
+	if !ok {
+		return "", fmt.Errorf("nanny: no application is found in the context")
+	}
+
+	return app.URL(name, pairs...)
+}
+
+func buildURL(r *route, params map[string]string) (string, error) {
+	var b strings.Builder
+	used := 0
+	path := r.path
+	for len(path) > 0 {
+		idx := strings.IndexAny(path, ":*")
+		if idx < 0 {
+			b.WriteString(path)
+			break
+		}
+
+		b.WriteString(path[:idx])
+		kind := path[idx]
+		path = path[idx+1:]
+
+		end := strings.IndexByte(path, '/')
+		if end < 0 {
+			end = len(path)
+		}
+		key := path[:end]
+		path = path[end:]
+
+		value, ok := params[key]
+		if !ok {
+			return "", fmt.Errorf("nanny: missing param %q for route %q", key, r.name)
+		}
+		used++
+
+		if kind == ':' {
+			if value == "" {
+				return "", fmt.Errorf("nanny: empty param %q for route %q", key, r.name)
+			}
+
+			b.WriteString(url.PathEscape(value))
+			continue
+		}
+
+		segments := strings.Split(strings.TrimPrefix(value, "/"), "/")
+		for i, s := range segments {
+			segments[i] = url.PathEscape(s)
+		}
+		b.WriteString(strings.Join(segments, "/"))
+	}
+
+	if used != len(params) {
+		return "", fmt.Errorf("nanny: unknown params for route %q", r.name)
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,95 @@
+package nanny
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_URL(t *testing.T) {
+	app := New()
+	app.GET("/users/:id", mockHandler, WithName("user.show"))
+	app.Group("/v1").GET("/users/:id/files/*filepath", mockHandler, WithName("user.file"))
+	app.GET("/users", mockHandler, WithName("user.list"))
+
+	tcs := map[string]struct {
+		name        string
+		pairs       []string
+		expected    string
+		expectedErr string
+	}{
+		"static": {
+			name:     "user.list",
+			expected: "/users",
+		},
+		"param": {
+			name:     "user.show",
+			pairs:    []string{"id", "1"},
+			expected: "/users/1",
+		},
+		"escaped-param": {
+			name:     "user.show",
+			pairs:    []string{"id", "a/b c"},
+			expected: "/users/a%2Fb%20c",
+		},
+		"catch-all": {
+			name:     "user.file",
+			pairs:    []string{"id", "1", "filepath", "/docs/my file.txt"},
+			expected: "/v1/users/1/files/docs/my%20file.txt",
+		},
+		"unknown-route": {
+			name:        "user.delete",
+			expectedErr: `nanny: route "user.delete" is not found`,
+		},
+		"missing-param": {
+			name:        "user.show",
+			expectedErr: `nanny: missing param "id" for route "user.show"`,
+		},
+		"empty-param": {
+			name:        "user.show",
+			pairs:       []string{"id", ""},
+			expectedErr: `nanny: empty param "id" for route "user.show"`,
+		},
+		"unknown-param": {
+			name:        "user.show",
+			pairs:       []string{"id", "1", "name", "nanny"},
+			expectedErr: `nanny: unknown params for route "user.show"`,
+		},
+		"odd-pairs": {
+			name:        "user.show",
+			pairs:       []string{"id"},
+			expectedErr: `nanny: params of route "user.show" must be pairs of key and value`,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			u, err := app.URL(tc.name, tc.pairs...)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, u)
+		})
+	}
+}
+
+func Test_URLFromCtx(t *testing.T) {
+	app := New()
+	app.GET("/users/:id", mockHandler, WithName("user.show"))
+	app.POST("/users", func(ctx context.Context, req Request) (interface{}, error) {
+		return URLFromCtx(ctx, "user.show", "id", "1")
+	})
+
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/users", nil))
+	require.Equal(t, "\"/users/1\"\n", rr.Body.String())
+
+	_, err := URLFromCtx(context.Background(), "user.show", "id", "1")
+	require.EqualError(t, err, "nanny: no application is found in the context")
+}
@@ -0,0 +1,372 @@
+package nanny
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const tagValidate = "validate"
+
+// Validator defines a validator of request DTOs. It's called after a request is decoded.
+type Validator interface {
+	// Validate validates a decoded request DTO.
+	Validate(obj interface{}) error
+}
+
+// Validatable defines a request DTO which validates itself.
+// Validate is called after validation rules from struct tags pass.
+type Validatable interface {
+	Validate() error
+}
+
+// WithValidator specifies the validator which will be used after decoding requests.
+// The default validator checks rules from validate tags and calls Validate if the DTO implements Validatable.
+func WithValidator(v Validator) RouteOptionFn {
+	return func(r *route) {
+		r.validator = v
+	}
+}
+
+// FieldError is the error of a field which fails a validation rule.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Error implements error interface.
+func (err *FieldError) Error() string {
+	return fmt.Sprintf("field %q %s", err.Field, err.Message)
+}
+
+// ValidationErrors contains errors of fields which fail validation rules.
+// It's responded with 422 status code and a list of failing fields by the default ErrorHandler.
+type ValidationErrors []*FieldError
+
+// Error implements error interface.
+func (errs ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// WriteTo implements CustomHTTPResponse. It encodes the errors as JSON format.
+func (errs ValidationErrors) WriteTo(w http.ResponseWriter) {
+	resp := struct {
+		Message string        `json:"message"`
+		Errors  []*FieldError `json:"errors"`
+	}{
+		Message: "Validation Failed",
+		Errors:  errs,
+	}
+
+	w.Header().Add(HeaderContentType, jsonScheme)
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func newValidator() Validator {
+	return defaultValidator{
+		logger: defaultLogger(),
+	}
+}
+
+// withDefaultValidator uses the default validator with the Logger of the application.
+// It's applied when routes are registered, so the Logger from WithLogger is used.
+func withDefaultValidator() RouteOptionFn {
+	return func(r *route) {
+		r.validator = defaultValidator{
+			logger: r.logger,
+		}
+	}
+}
+
+// defaultValidator validates rules from validate tags.
+// Supported rules are required, min, max, len, email and oneof, e.g. `validate:"required,min=1,max=100"`.
+// Rules other than required are skipped for nil pointers.
+// Unknown rules, invalid params and rules which don't support the type of a field are configuration errors:
+// they are logged once per struct type and Validate returns an error, so unvalidated input never reaches handlers.
+type defaultValidator struct {
+	logger Logger
+}
+
+// validationFieldsResult is a cached result of parsing validation rules of a struct type.
+type validationFieldsResult struct {
+	fields []*validationField
+	err    error
+}
+
+type validationRule struct {
+	name  string
+	param string
+}
+
+type validationField struct {
+	index []int
+	name  string
+	rules []validationRule
+}
+
+// validationFields caches fields with validation rules of struct types.
+var validationFields sync.Map
+
+func (dv defaultValidator) Validate(obj interface{}) error {
+	v := reflect.ValueOf(obj)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	var errs ValidationErrors
+	if v.Kind() == reflect.Struct {
+		if err := dv.validateStruct(v, "", &errs); err != nil {
+			return err
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	if validatable, ok := obj.(Validatable); ok {
+		return validatable.Validate()
+	}
+
+	return nil
+}
+
+func (dv defaultValidator) validateStruct(v reflect.Value, prefix string, errs *ValidationErrors) error {
+	fields, err := dv.getValidationFields(v.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		fv := v.FieldByIndex(f.index)
+		name := prefix + f.name
+		for _, rule := range f.rules {
+			if fieldErr := checkRule(fv, rule); fieldErr != "" {
+				*errs = append(*errs, &FieldError{Field: name, Rule: rule.name, Message: fieldErr})
+				// report only the first failing rule of a field
+				break
+			}
+		}
+
+		for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+
+		if fv.Kind() == reflect.Struct && fv.Type() != reflect.TypeOf(time.Time{}) {
+			if err := dv.validateStruct(fv, name+".", errs); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (dv defaultValidator) getValidationFields(t reflect.Type) ([]*validationField, error) {
+	if cached, ok := validationFields.Load(t); ok {
+		result := cached.(*validationFieldsResult)
+		return result.fields, result.err
+	}
+
+	var invalidRules []string
+	result := &validationFieldsResult{
+		fields: parseValidationFields(t, nil, &invalidRules),
+	}
+	if len(invalidRules) > 0 {
+		result.err = fmt.Errorf("nanny: invalid validation rules in %s: %s", t, strings.Join(invalidRules, "; "))
+	}
+
+	// the error is logged only by the first caller which parses the type
+	if _, loaded := validationFields.LoadOrStore(t, result); !loaded && result.err != nil && dv.logger != nil {
+		dv.logger.Println(result.err)
+	}
+
+	return result.fields, result.err
+}
+
+func parseValidationFields(t reflect.Type, parentIndex []int, invalidRules *[]string) []*validationField {
+	var fields []*validationField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		index := append(append([]int{}, parentIndex...), i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			fields = append(fields, parseValidationFields(sf.Type, index, invalidRules)...)
+			continue
+		}
+
+		// unexported fields
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		f := &validationField{
+			index: index,
+			name:  fieldName(sf),
+		}
+
+		for _, r := range strings.Split(sf.Tag.Get(tagValidate), ",") {
+			if r == "" {
+				continue
+			}
+
+			rule := validationRule{name: r}
+			if idx := strings.IndexByte(r, '='); idx >= 0 {
+				rule.name, rule.param = r[:idx], r[idx+1:]
+			}
+
+			if err := checkRuleSupport(sf.Type, rule); err != nil {
+				*invalidRules = append(*invalidRules, fmt.Sprintf("rule %q of field %s: %v", r, sf.Name, err))
+				continue
+			}
+
+			f.rules = append(f.rules, rule)
+		}
+
+		fields = append(fields, f)
+	}
+
+	return fields
+}
+
+// fieldName returns the name of a field in the request, which is from tags or the field name itself.
+func fieldName(sf reflect.StructField) string {
+	for _, tag := range []string{"json", tagPath, tagQuery, tagHeader, tagCookie, tagForm} {
+		if name := tagName(sf, tag); name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return sf.Name
+}
+
+// checkRuleSupport returns an error if a rule can't be checked against values of a type.
+func checkRuleSupport(t reflect.Type, rule validationRule) error {
+	if rule.name == "required" {
+		return nil
+	}
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch rule.name {
+	case "min", "max", "len":
+		if _, err := strconv.ParseFloat(rule.param, 64); err != nil {
+			return fmt.Errorf("invalid param %q", rule.param)
+		}
+
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64, reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+			return nil
+		}
+	case "email":
+		if t.Kind() == reflect.String {
+			return nil
+		}
+	case "oneof":
+		return nil
+	default:
+		return errors.New("unknown rule")
+	}
+
+	return fmt.Errorf("rule isn't supported for %s", t)
+}
+
+// checkRule checks a rule against a value. It returns a message if the value fails the rule.
+// Rules are checked by checkRuleSupport in advance.
+func checkRule(v reflect.Value, rule validationRule) string {
+	if rule.name == "required" {
+		if v.IsZero() {
+			return "is required"
+		}
+		return ""
+	}
+
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	switch rule.name {
+	case "min", "max", "len":
+		return checkSize(v, rule)
+	case "email":
+		addr, err := mail.ParseAddress(v.String())
+		if err != nil || addr.Address != v.String() {
+			return "must be a valid email address"
+		}
+	case "oneof":
+		options := strings.Fields(rule.param)
+		value := fmt.Sprint(v.Interface())
+		for _, o := range options {
+			if o == value {
+				return ""
+			}
+		}
+		return fmt.Sprintf("must be one of [%s]", strings.Join(options, " "))
+	}
+
+	return ""
+}
+
+// checkSize checks min, max and len rules. Numbers are compared by their values,
+// strings by their number of characters and slices and maps by their lengths.
+func checkSize(v reflect.Value, rule validationRule) string {
+	limit, _ := strconv.ParseFloat(rule.param, 64)
+
+	var size float64
+	unit := ""
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		size = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		size = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		size = v.Float()
+	case reflect.String:
+		size = float64(utf8.RuneCountInString(v.String()))
+		unit = " characters"
+	default:
+		size = float64(v.Len())
+		unit = " items"
+	}
+
+	switch {
+	case rule.name == "min" && size < limit:
+		if unit == "" {
+			return fmt.Sprintf("must be at least %s", rule.param)
+		}
+		return fmt.Sprintf("must have at least %s%s", rule.param, unit)
+	case rule.name == "max" && size > limit:
+		if unit == "" {
+			return fmt.Sprintf("must be at most %s", rule.param)
+		}
+		return fmt.Sprintf("must have at most %s%s", rule.param, unit)
+	case rule.name == "len" && size != limit:
+		if unit == "" {
+			return fmt.Sprintf("must be %s", rule.param)
+		}
+		return fmt.Sprintf("must have %s%s", rule.param, unit)
+	}
+
+	return ""
+}
@@ -0,0 +1,180 @@
+package nanny
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockAddress struct {
+	City string `json:"city" validate:"required"`
+}
+
+type mockValidationRequest struct {
+	Email   string       `json:"email" validate:"required,email"`
+	Name    string       `json:"name" validate:"min=2,max=5"`
+	Age     int          `json:"age" validate:"min=18"`
+	Code    string       `json:"code" validate:"len=3"`
+	Role    string       `json:"role" validate:"oneof=admin user"`
+	Tags    []string     `json:"tags" validate:"max=2"`
+	Limit   *int         `query:"limit" validate:"max=100"`
+	Address *mockAddress `json:"address"`
+}
+
+type mockValidatableRequest struct {
+	Password string `json:"password"`
+	Confirm  string `json:"confirm"`
+}
+
+func (r *mockValidatableRequest) Validate() error {
+	if r.Password != r.Confirm {
+		return ValidationErrors{{Field: "confirm", Rule: "match", Message: "must match password"}}
+	}
+
+	return nil
+}
+
+func Test_defaultValidator(t *testing.T) {
+	v := newValidator()
+
+	t.Run("valid", func(t *testing.T) {
+		limit := 10
+		err := v.Validate(&mockValidationRequest{
+			Email:   "john@example.com",
+			Name:    "John",
+			Age:     20,
+			Code:    "abc",
+			Role:    "admin",
+			Tags:    []string{"a"},
+			Limit:   &limit,
+			Address: &mockAddress{City: "Hanoi"},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		limit := 1000
+		err := v.Validate(&mockValidationRequest{
+			Email:   "john",
+			Name:    "J",
+			Age:     10,
+			Code:    "ab",
+			Role:    "guest",
+			Tags:    []string{"a", "b", "c"},
+			Limit:   &limit,
+			Address: &mockAddress{},
+		})
+
+		var errs ValidationErrors
+		require.True(t, errors.As(err, &errs))
+		require.Equal(t, ValidationErrors{
+			{Field: "email", Rule: "email", Message: "must be a valid email address"},
+			{Field: "name", Rule: "min", Message: "must have at least 2 characters"},
+			{Field: "age", Rule: "min", Message: "must be at least 18"},
+			{Field: "code", Rule: "len", Message: "must have 3 characters"},
+			{Field: "role", Rule: "oneof", Message: "must be one of [admin user]"},
+			{Field: "tags", Rule: "max", Message: "must have at most 2 items"},
+			{Field: "limit", Rule: "max", Message: "must be at most 100"},
+			{Field: "address.city", Rule: "required", Message: "is required"},
+		}, errs)
+	})
+
+	t.Run("validatable", func(t *testing.T) {
+		err := v.Validate(&mockValidatableRequest{Password: "a", Confirm: "b"})
+		require.EqualError(t, err, `field "confirm" must match password`)
+	})
+
+	t.Run("invalid-rules", func(t *testing.T) {
+		type invalidRuleRequest struct {
+			ID    string `validate:"required,uuid"`
+			Age   int    `validate:"required,gte=3"`
+			Tags  []int  `validate:"min=abc"`
+			Admin bool   `validate:"max=1"`
+			Name  string `validate:"requried"`
+		}
+
+		buf := &bytes.Buffer{}
+		v := defaultValidator{logger: log.New(buf, "", 0)}
+		expectedErr := `nanny: invalid validation rules in nanny.invalidRuleRequest: ` +
+			`rule "uuid" of field ID: unknown rule; rule "gte=3" of field Age: unknown rule; ` +
+			`rule "min=abc" of field Tags: invalid param "abc"; rule "max=1" of field Admin: rule isn't supported for bool; ` +
+			`rule "requried" of field Name: unknown rule`
+		require.EqualError(t, v.Validate(&invalidRuleRequest{ID: "1", Age: 1}), expectedErr)
+		require.EqualError(t, v.Validate(&struct{ Nested invalidRuleRequest }{}), expectedErr)
+
+		// the error is logged once
+		require.Equal(t, expectedErr+"\n", buf.String())
+	})
+
+}
+
+func Test_ValidationErrors_WriteTo(t *testing.T) {
+	w := httptest.NewRecorder()
+	ValidationErrors{{Field: "email", Rule: "required", Message: "is required"}}.WriteTo(w)
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	require.JSONEq(t, `{"message":"Validation Failed","errors":[{"field":"email","rule":"required","message":"is required"}]}`, w.Body.String())
+}
+
+type mockValidatorFn func(obj interface{}) error
+
+func (fn mockValidatorFn) Validate(obj interface{}) error {
+	return fn(obj)
+}
+
+func Test_WithValidator(t *testing.T) {
+	app := New()
+	app.POST("/users", func(ctx context.Context, req Request) (interface{}, error) {
+		reqObj := &mockValidationRequest{}
+		if err := req.Decode(reqObj); err != nil {
+			return nil, err
+		}
+
+		return reqObj, nil
+	})
+	app.POST("/custom", func(ctx context.Context, req Request) (interface{}, error) {
+		return nil, req.Decode(&mockValidationRequest{})
+	}, WithValidator(mockValidatorFn(func(obj interface{}) error {
+		return HTTPError{Code: http.StatusBadRequest, Message: "custom"}
+	})))
+
+	t.Run("default", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"John"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		require.Contains(t, w.Body.String(), `"field":"email"`)
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/custom", nil))
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func Test_defaultValidator_appLogger(t *testing.T) {
+	type badTagRequest struct {
+		Name string `json:"name" validate:"required,gte=3"`
+	}
+
+	buf := &bytes.Buffer{}
+	app := New(WithLogger(log.New(buf, "", 0)))
+	app.POST("/users", func(ctx context.Context, req Request) (interface{}, error) {
+		return nil, req.Decode(&badTagRequest{})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"John"}`))
+	req.Header.Set(HeaderContentType, "application/json")
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Contains(t, buf.String(), `rule "gte=3" of field Name: unknown rule`)
+}